@@ -4,9 +4,11 @@ package gonduit
 import (
 	"context"
 	"crypto/sha1"
+	"errors"
 	"fmt"
 	"io"
 	"strconv"
+	"sync"
 	"time"
 
 	"github.com/uber/gonduit/core"
@@ -15,14 +17,51 @@ import (
 	"github.com/uber/gonduit/responses"
 )
 
-// Conn is a connection to the conduit API.
+// Conn is a connection to the conduit API. It is safe for concurrent use by
+// multiple goroutines, including while a session is being (re-)established.
+//
+// Conn holds its own copy of the options passed to Dial, so the caller's
+// options are never modified.
 type Conn struct {
 	host         string
 	user         string
 	capabilities *responses.ConduitCapabilitiesResponse
-	Session      *entities.Session
-	dialer       *Dialer
-	options      *core.ClientOptions
+	negotiated   *negotiatedCapabilities
+	// Session is the session established by Connect.
+	//
+	// Deprecated: Session is replaced when an expired session is
+	// re-established, so reading it is not safe for concurrent use. Use
+	// CurrentSession instead.
+	Session *entities.Session
+	dialer  *Dialer
+	options *core.ClientOptions
+
+	// mu guards options and Session, which are replaced when a session is
+	// established. The options themselves are never modified.
+	mu sync.RWMutex
+	// connectMu serializes re-establishing expired sessions.
+	connectMu sync.Mutex
+}
+
+// Capabilities returns the capabilities advertised by the server when the
+// connection was dialed.
+func (c *Conn) Capabilities() *responses.ConduitCapabilitiesResponse {
+	return c.capabilities
+}
+
+// AuthScheme returns the authentication scheme negotiated with the server.
+func (c *Conn) AuthScheme() core.AuthScheme {
+	return c.negotiated.authScheme
+}
+
+// InputEncoding returns the request encoding negotiated with the server.
+func (c *Conn) InputEncoding() core.Encoding {
+	return c.negotiated.input
+}
+
+// OutputEncoding returns the response encoding negotiated with the server.
+func (c *Conn) OutputEncoding() core.Encoding {
+	return c.negotiated.output
 }
 
 func getAuthToken() string {
@@ -46,8 +85,9 @@ func (c *Conn) Connect() error {
 // ConnectContext calls conduit.connect to open an authenticated session for future
 // requests, passing through the given context.
 func (c *Conn) ConnectContext(ctx context.Context) error {
+	options := c.getOptions()
 	authToken := getAuthToken()
-	authSig := getAuthSignature(authToken, c.options.Cert)
+	authSig := getAuthSignature(authToken, options.Cert)
 
 	var resp responses.ConduitConnectResponse
 
@@ -56,23 +96,73 @@ func (c *Conn) ConnectContext(ctx context.Context) error {
 		ClientVersion:     c.dialer.ClientVersion,
 		ClientDescription: c.dialer.ClientDescription,
 		Host:              c.host,
-		User:              c.options.CertUser,
+		User:              options.CertUser,
 		AuthToken:         authToken,
 		AuthSignature:     authSig,
 	}, &resp); err != nil {
 		return err
 	}
 
+	withSession := *options
+	withSession.SessionKey = resp.SessionKey
+
+	c.mu.Lock()
 	c.Session = &entities.Session{
 		SessionKey:   resp.SessionKey,
 		ConnectionID: resp.ConnectionID,
 	}
-
-	c.options.SessionKey = resp.SessionKey
+	c.options = &withSession
+	c.mu.Unlock()
 
 	return nil
 }
 
+// CurrentSession returns the session established by Connect, or nil if
+// Connect was not called.
+func (c *Conn) CurrentSession() *entities.Session {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.Session
+}
+
+// getOptions returns the options used by calls, including the current
+// session key.
+func (c *Conn) getOptions() *core.ClientOptions {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	return c.options
+}
+
+// reconnect establishes a new session to replace the one identified by
+// staleKey. If another call already replaced it, the new session is reused.
+func (c *Conn) reconnect(ctx context.Context, staleKey string) error {
+	c.connectMu.Lock()
+	defer c.connectMu.Unlock()
+
+	if c.getOptions().SessionKey != staleKey {
+		return nil
+	}
+
+	return c.ConnectContext(ctx)
+}
+
+// shouldReconnect reports whether a call which failed with err should be
+// retried after establishing a new session. Only calls made with a session
+// established by Connect are retried.
+func (c *Conn) shouldReconnect(
+	method string,
+	options *core.ClientOptions,
+	err error,
+) bool {
+	return errors.Is(err, core.ErrInvalidSession) &&
+		method != "conduit.connect" &&
+		c.negotiated.authScheme == core.AuthSchemeSession &&
+		options.Cert != "" &&
+		options.SessionKey != ""
+}
+
 // Call allows you to make a raw conduit method call. Params will be marshalled
 // as JSON and the result JSON will be unmarshalled into the result interface{}.
 //
@@ -93,17 +183,41 @@ func (c *Conn) Call(
 //
 // This is primarily useful for calling conduit endpoints that aren't
 // specifically supported by other methods in this package.
+//
+// When using certificate authentication, calls failing because the session
+// expired are retried once after calling conduit.connect again.
 func (c *Conn) CallContext(
 	ctx context.Context,
 	method string,
 	params interface{},
 	result interface{},
+) error {
+	options := c.getOptions()
+
+	err := c.performCall(ctx, method, params, result, options)
+	if !c.shouldReconnect(method, options, err) {
+		return err
+	}
+
+	if err := c.reconnect(ctx, options.SessionKey); err != nil {
+		return err
+	}
+
+	return c.performCall(ctx, method, params, result, c.getOptions())
+}
+
+func (c *Conn) performCall(
+	ctx context.Context,
+	method string,
+	params interface{},
+	result interface{},
+	options *core.ClientOptions,
 ) error {
 	return core.PerformCallContext(
 		ctx,
 		core.GetEndpointURI(c.host, method),
 		params,
-		&result,
-		c.options,
+		result,
+		options,
 	)
 }
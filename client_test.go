@@ -0,0 +1,220 @@
+package gonduit
+
+import (
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/core"
+	"github.com/uber/gonduit/requests"
+	"github.com/uber/gonduit/responses"
+	"github.com/uber/gonduit/test/server"
+)
+
+// sessionServer is a test server which issues session keys on
+// conduit.connect and rejects calls made with an expired one.
+type sessionServer struct {
+	*server.Server
+
+	mu       sync.Mutex
+	sessions int
+	connects int
+}
+
+func newSessionServer() *sessionServer {
+	s := &sessionServer{Server: server.New()}
+
+	s.RegisterMethod("conduit.getcapabilities", 200, server.ResponseFromJSON(`{
+		"result": {
+			"authentication": ["session"],
+			"input": ["urlencoded"],
+			"output": ["json"]
+		}
+	}`))
+
+	s.RegisterMethodFunc("conduit.connect", func(
+		params map[string]interface{},
+	) (int, map[string]interface{}) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.connects++
+		s.sessions++
+
+		return 200, map[string]interface{}{
+			"result": map[string]interface{}{
+				"connectionID": s.sessions,
+				"sessionKey":   s.sessionKey(),
+			},
+		}
+	})
+
+	s.RegisterMethodFunc("phid.lookup", func(
+		params map[string]interface{},
+	) (int, map[string]interface{}) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		metadata, _ := params["__conduit__"].(map[string]interface{})
+		if metadata["sessionKey"] != s.sessionKey() {
+			return 200, map[string]interface{}{
+				"error_code": "ERR-INVALID-SESSION",
+				"error_info": "Session key is not present.",
+			}
+		}
+
+		return 200, map[string]interface{}{"result": map[string]interface{}{}}
+	})
+
+	return s
+}
+
+func (s *sessionServer) sessionKey() string {
+	return "session-" + strconv.Itoa(s.sessions)
+}
+
+// expire invalidates the current session.
+func (s *sessionServer) expire() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions++
+}
+
+func dialSession(t *testing.T, s *sessionServer) *Conn {
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		Cert:     "some-certificate",
+		CertUser: "alice",
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, c.Connect())
+
+	return c
+}
+
+func TestConn_withExpiredSession(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	c := dialSession(t, s)
+	s.expire()
+
+	_, err := c.PHIDLookup(requests.PHIDLookupRequest{Names: []string{"T1"}})
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, s.connects)
+	assert.Equal(t, "session-3", c.CurrentSession().SessionKey)
+}
+
+func TestConn_withExpiredSessionAndConcurrentCalls(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	c := dialSession(t, s)
+	s.expire()
+
+	var wg sync.WaitGroup
+	errs := make([]error, 10)
+	for i := range errs {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var res responses.PHIDLookupResponse
+			errs[i] = c.Call(
+				"phid.lookup",
+				&requests.PHIDLookupRequest{Names: []string{"T1"}},
+				&res,
+			)
+		}(i)
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		assert.Nil(t, err)
+	}
+	// Concurrent calls share a single new session.
+	assert.Equal(t, 2, s.connects)
+}
+
+func TestConn_withInvalidSessionAndNoConnect(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		Cert:     "some-certificate",
+		CertUser: "alice",
+	})
+	assert.Nil(t, err)
+
+	_, err = c.PHIDLookup(requests.PHIDLookupRequest{Names: []string{"T1"}})
+
+	assert.True(t, errors.Is(err, core.ErrInvalidSession))
+	assert.Equal(t, 0, s.connects)
+}
+
+func TestConn_withConcurrentCallsAndReconnects(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	c := dialSession(t, s)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 100)
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := c.PHIDLookup(requests.PHIDLookupRequest{
+				Names: []string{"T1"},
+			})
+			errs <- err
+		}()
+
+		go func(i int) {
+			defer wg.Done()
+
+			switch i % 3 {
+			case 0:
+				s.expire()
+			case 1:
+				errs <- c.Connect()
+			default:
+				assert.NotNil(t, c.CurrentSession())
+			}
+		}(i)
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		// A call may still see an invalid session if the session expired
+		// again after it was re-established.
+		if err != nil {
+			assert.True(t, errors.Is(err, core.ErrInvalidSession))
+		}
+	}
+
+	_, err := c.PHIDLookup(requests.PHIDLookupRequest{Names: []string{"T1"}})
+	assert.Nil(t, err)
+}
+
+func TestConn_Connect_leavesOptionsUntouched(t *testing.T) {
+	s := newSessionServer()
+	defer s.Close()
+
+	options := &core.ClientOptions{
+		Cert:     "some-certificate",
+		CertUser: "alice",
+	}
+	c, err := Dial(s.GetURL(), options)
+	assert.Nil(t, err)
+	assert.Nil(t, c.Connect())
+
+	assert.Equal(t, "session-1", c.CurrentSession().SessionKey)
+	assert.Equal(t, "", options.SessionKey)
+}
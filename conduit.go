@@ -1,15 +1,27 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
 
 // ConduitQuery performs a call to conduit.query.
 func (c *Conn) ConduitQuery() (*responses.ConduitQueryResponse, error) {
+	ctx := context.Background()
+	return c.ConduitQueryContext(ctx)
+}
+
+// ConduitQueryContext performs a call to conduit.query, passing through the
+// given context.
+func (c *Conn) ConduitQueryContext(
+	ctx context.Context,
+) (*responses.ConduitQueryResponse, error) {
 	var res responses.ConduitQueryResponse
 
-	if err := c.Call("conduit.query", &requests.Request{}, &res); err != nil {
+	if err := c.CallContext(
+		ctx, "conduit.query", &requests.Request{}, &res); err != nil {
 		return nil, err
 	}
 
@@ -1,10 +1,12 @@
 package gonduit
 
 import (
+	"context"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/uber/gonduit/core"
+	"github.com/uber/gonduit/responses"
 	"github.com/uber/gonduit/test/server"
 )
 
@@ -40,3 +42,63 @@ func TestConduitQuery(t *testing.T) {
 		(*r)["phid.query"].Return,
 	)
 }
+
+func TestConduitQuery_withInterceptor(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod("conduit.query", 200, server.ResponseFromJSON(
+		`{"result":{}}`,
+	))
+
+	var methods []string
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+		Interceptors: []core.Interceptor{core.ObserverInterceptor(
+			func(ctx context.Context, info core.CallInfo) {
+				methods = append(methods, info.Method)
+			},
+		)},
+	})
+	assert.Nil(t, err)
+
+	_, err = c.ConduitQuery()
+	assert.Nil(t, err)
+
+	assert.Equal(
+		t,
+		[]string{"conduit.getcapabilities", "conduit.query"},
+		methods,
+	)
+}
+
+func TestConduitQuery_withInterceptorResultType(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod("conduit.query", 200, server.ResponseFromJSON(
+		`{"result":{"conduit.query":{"description":"Returns methods."}}}`,
+	))
+
+	var result interface{}
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+		Interceptors: []core.Interceptor{core.ObserverInterceptor(
+			func(ctx context.Context, info core.CallInfo) {
+				if info.Method == "conduit.query" {
+					result = info.Result
+				}
+			},
+		)},
+	})
+	assert.Nil(t, err)
+
+	res, err := c.ConduitQuery()
+	assert.Nil(t, err)
+
+	typed, ok := result.(*responses.ConduitQueryResponse)
+	if assert.True(t, ok, "unexpected result type %T", result) {
+		assert.Equal(t, res, typed)
+		assert.Equal(t, "Returns methods.", (*typed)["conduit.query"].Description)
+	}
+}
@@ -59,3 +59,112 @@ const (
 	// Value is empty string on purpose.
 	DifferentialGetCommitMessageRead DifferentialGetCommitMessageEditType = ""
 )
+
+// DifferentialChangeType is the type of change of a path in a diff.
+type DifferentialChangeType int
+
+const (
+	// DifferentialChangeTypeAdd is an added file.
+	DifferentialChangeTypeAdd DifferentialChangeType = 1
+	// DifferentialChangeTypeChange is a modified file.
+	DifferentialChangeTypeChange DifferentialChangeType = 2
+	// DifferentialChangeTypeDelete is a deleted file.
+	DifferentialChangeTypeDelete DifferentialChangeType = 3
+	// DifferentialChangeTypeMoveAway is the source of a moved file.
+	DifferentialChangeTypeMoveAway DifferentialChangeType = 4
+	// DifferentialChangeTypeCopyAway is the source of a copied file.
+	DifferentialChangeTypeCopyAway DifferentialChangeType = 5
+	// DifferentialChangeTypeMoveHere is the destination of a moved file.
+	DifferentialChangeTypeMoveHere DifferentialChangeType = 6
+	// DifferentialChangeTypeCopyHere is the destination of a copied file.
+	DifferentialChangeTypeCopyHere DifferentialChangeType = 7
+	// DifferentialChangeTypeMultiCopy is the source of a file copied to
+	// several destinations.
+	DifferentialChangeTypeMultiCopy DifferentialChangeType = 8
+)
+
+// DifferentialFileType is the type of a file in a diff.
+type DifferentialFileType int
+
+const (
+	// DifferentialFileTypeText is a text file.
+	DifferentialFileTypeText DifferentialFileType = 1
+	// DifferentialFileTypeImage is an image.
+	DifferentialFileTypeImage DifferentialFileType = 2
+	// DifferentialFileTypeBinary is a binary file.
+	DifferentialFileTypeBinary DifferentialFileType = 3
+	// DifferentialFileTypeDirectory is a directory.
+	DifferentialFileTypeDirectory DifferentialFileType = 4
+	// DifferentialFileTypeSymlink is a symbolic link.
+	DifferentialFileTypeSymlink DifferentialFileType = 5
+)
+
+// DifferentialLintStatus is the lint status of a diff.
+type DifferentialLintStatus string
+
+const (
+	// DifferentialLintStatusNone means no linters ran.
+	DifferentialLintStatusNone DifferentialLintStatus = "none"
+	// DifferentialLintStatusSkip means linting was skipped.
+	DifferentialLintStatusSkip DifferentialLintStatus = "skip"
+	// DifferentialLintStatusOkay means there were no lint messages.
+	DifferentialLintStatusOkay DifferentialLintStatus = "okay"
+	// DifferentialLintStatusWarn means there were lint warnings.
+	DifferentialLintStatusWarn DifferentialLintStatus = "warn"
+	// DifferentialLintStatusFail means there were lint errors.
+	DifferentialLintStatusFail DifferentialLintStatus = "fail"
+)
+
+// DifferentialUnitStatus is the unit test status of a diff.
+type DifferentialUnitStatus string
+
+const (
+	// DifferentialUnitStatusNone means no tests ran.
+	DifferentialUnitStatusNone DifferentialUnitStatus = "none"
+	// DifferentialUnitStatusSkip means tests were skipped.
+	DifferentialUnitStatusSkip DifferentialUnitStatus = "skip"
+	// DifferentialUnitStatusOkay means all tests passed.
+	DifferentialUnitStatusOkay DifferentialUnitStatus = "okay"
+	// DifferentialUnitStatusWarn means tests passed with warnings.
+	DifferentialUnitStatusWarn DifferentialUnitStatus = "warn"
+	// DifferentialUnitStatusFail means tests failed.
+	DifferentialUnitStatusFail DifferentialUnitStatus = "fail"
+	// DifferentialUnitStatusPostponed means tests will run later.
+	DifferentialUnitStatusPostponed DifferentialUnitStatus = "postponed"
+)
+
+// DifferentialCommentAction is an action taken with differential.createcomment.
+type DifferentialCommentAction string
+
+const (
+	// DifferentialCommentActionComment only comments.
+	DifferentialCommentActionComment DifferentialCommentAction = "comment"
+	// DifferentialCommentActionAccept accepts the revision.
+	DifferentialCommentActionAccept DifferentialCommentAction = "accept"
+	// DifferentialCommentActionReject requests changes to the revision.
+	DifferentialCommentActionReject DifferentialCommentAction = "reject"
+	// DifferentialCommentActionRethink plans changes to the revision.
+	DifferentialCommentActionRethink DifferentialCommentAction = "rethink"
+	// DifferentialCommentActionRequestReview requests review of the revision.
+	DifferentialCommentActionRequestReview DifferentialCommentAction = "request_review"
+	// DifferentialCommentActionResign resigns as a reviewer.
+	DifferentialCommentActionResign DifferentialCommentAction = "resign"
+	// DifferentialCommentActionAbandon abandons the revision.
+	DifferentialCommentActionAbandon DifferentialCommentAction = "abandon"
+	// DifferentialCommentActionReclaim reclaims the revision.
+	DifferentialCommentActionReclaim DifferentialCommentAction = "reclaim"
+)
+
+// Names of the diff properties Arcanist stores lint and unit results in.
+const (
+	// DifferentialDiffPropertyLint holds lint messages.
+	DifferentialDiffPropertyLint = "arc:lint"
+	// DifferentialDiffPropertyLintExcuse holds the reason lint errors were
+	// ignored.
+	DifferentialDiffPropertyLintExcuse = "arc:lint-excuse"
+	// DifferentialDiffPropertyUnit holds unit test results.
+	DifferentialDiffPropertyUnit = "arc:unit"
+	// DifferentialDiffPropertyUnitExcuse holds the reason unit test
+	// failures were ignored.
+	DifferentialDiffPropertyUnitExcuse = "arc:unit-excuse"
+)
@@ -0,0 +1,66 @@
+package constants
+
+// HarbormasterMessageType is the kind of message sent to a build target with
+// harbormaster.sendmessage.
+type HarbormasterMessageType string
+
+const (
+	// HarbormasterMessageTypePass marks the build target as passed.
+	HarbormasterMessageTypePass HarbormasterMessageType = "pass"
+	// HarbormasterMessageTypeFail marks the build target as failed.
+	HarbormasterMessageTypeFail HarbormasterMessageType = "fail"
+	// HarbormasterMessageTypeWork reports unit and lint results without
+	// changing the state of the build target, which keeps waiting for a
+	// pass or fail message.
+	HarbormasterMessageTypeWork HarbormasterMessageType = "work"
+)
+
+// HarbormasterUnitResultStatus is the outcome of a unit test reported to
+// Harbormaster.
+type HarbormasterUnitResultStatus string
+
+const (
+	// HarbormasterUnitResultPass is a passing test.
+	HarbormasterUnitResultPass HarbormasterUnitResultStatus = "pass"
+	// HarbormasterUnitResultFail is a failing test.
+	HarbormasterUnitResultFail HarbormasterUnitResultStatus = "fail"
+	// HarbormasterUnitResultSkip is a skipped test.
+	HarbormasterUnitResultSkip HarbormasterUnitResultStatus = "skip"
+	// HarbormasterUnitResultBroken is a test which could not run.
+	HarbormasterUnitResultBroken HarbormasterUnitResultStatus = "broken"
+	// HarbormasterUnitResultUnsound is a test which passed but whose result
+	// can not be trusted.
+	HarbormasterUnitResultUnsound HarbormasterUnitResultStatus = "unsound"
+)
+
+// HarbormasterLintSeverity is the severity of a lint message reported to
+// Harbormaster.
+type HarbormasterLintSeverity string
+
+const (
+	// HarbormasterLintSeverityAdvice is an informational message.
+	HarbormasterLintSeverityAdvice HarbormasterLintSeverity = "advice"
+	// HarbormasterLintSeverityAutofix is a problem which can be fixed
+	// automatically.
+	HarbormasterLintSeverityAutofix HarbormasterLintSeverity = "autofix"
+	// HarbormasterLintSeverityWarning is a warning.
+	HarbormasterLintSeverityWarning HarbormasterLintSeverity = "warning"
+	// HarbormasterLintSeverityError is an error.
+	HarbormasterLintSeverityError HarbormasterLintSeverity = "error"
+	// HarbormasterLintSeverityDisabled is a message of a disabled linter.
+	HarbormasterLintSeverityDisabled HarbormasterLintSeverity = "disabled"
+)
+
+// HarbormasterBuildCommand is a command controlling a Harbormaster build.
+type HarbormasterBuildCommand string
+
+const (
+	// HarbormasterBuildCommandRestart restarts a completed build.
+	HarbormasterBuildCommandRestart HarbormasterBuildCommand = "restart"
+	// HarbormasterBuildCommandPause pauses a running build.
+	HarbormasterBuildCommandPause HarbormasterBuildCommand = "pause"
+	// HarbormasterBuildCommandResume resumes a paused build.
+	HarbormasterBuildCommandResume HarbormasterBuildCommand = "resume"
+	// HarbormasterBuildCommandAbort aborts a build which has not completed.
+	HarbormasterBuildCommandAbort HarbormasterBuildCommand = "abort"
+)
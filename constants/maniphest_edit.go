@@ -0,0 +1,38 @@
+package constants
+
+// ManiphestEditStatus is a status a task can be set to with maniphest.edit.
+type ManiphestEditStatus string
+
+const (
+	// ManiphestEditStatusOpen reopens a task.
+	ManiphestEditStatusOpen ManiphestEditStatus = "open"
+	// ManiphestEditStatusResolved closes a task as resolved.
+	ManiphestEditStatusResolved ManiphestEditStatus = "resolved"
+	// ManiphestEditStatusWontFix closes a task as wontfix.
+	ManiphestEditStatusWontFix ManiphestEditStatus = "wontfix"
+	// ManiphestEditStatusInvalid closes a task as invalid.
+	ManiphestEditStatusInvalid ManiphestEditStatus = "invalid"
+	// ManiphestEditStatusDuplicate closes a task as a duplicate.
+	ManiphestEditStatusDuplicate ManiphestEditStatus = "duplicate"
+	// ManiphestEditStatusSpite closes a task out of spite.
+	ManiphestEditStatusSpite ManiphestEditStatus = "spite"
+)
+
+// ManiphestEditPriority is a priority a task can be set to with
+// maniphest.edit.
+type ManiphestEditPriority string
+
+const (
+	// ManiphestEditPriorityUnbreak is the "Unbreak Now!" priority.
+	ManiphestEditPriorityUnbreak ManiphestEditPriority = "unbreak"
+	// ManiphestEditPriorityTriage is the "Needs Triage" priority.
+	ManiphestEditPriorityTriage ManiphestEditPriority = "triage"
+	// ManiphestEditPriorityHigh is the "High" priority.
+	ManiphestEditPriorityHigh ManiphestEditPriority = "high"
+	// ManiphestEditPriorityNormal is the "Normal" priority.
+	ManiphestEditPriorityNormal ManiphestEditPriority = "normal"
+	// ManiphestEditPriorityLow is the "Low" priority.
+	ManiphestEditPriorityLow ManiphestEditPriority = "low"
+	// ManiphestEditPriorityWish is the "Wishlist" priority.
+	ManiphestEditPriorityWish ManiphestEditPriority = "wish"
+)
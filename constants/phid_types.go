@@ -12,4 +12,14 @@ const (
 
 	// PhidTypeDifferentialRevision is the PHID of a differential revision.
 	PhidTypeDifferentialRevision PhidType = "DREV"
+
+	// PhidTypeDifferentialDiff is the PHID of a differential diff.
+	PhidTypeDifferentialDiff PhidType = "DIFF"
+
+	// PhidTypeHarbormasterBuildable is the PHID of a Harbormaster buildable.
+	PhidTypeHarbormasterBuildable PhidType = "HMBB"
+
+	// PhidTypeHarbormasterBuildTarget is the PHID of a Harbormaster build
+	// target.
+	PhidTypeHarbormasterBuildTarget PhidType = "HMBT"
 )
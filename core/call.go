@@ -7,6 +7,7 @@ import (
 	"io/ioutil"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/karlseguin/typed"
 )
@@ -20,7 +21,10 @@ func GetEndpointURI(host string, method string) string {
 // parameters. The response will be unmarshaled into the passed result struct.
 //
 // If an error is encountered, it will be unmarshalled into a ConduitError
-// struct.
+// struct. Transient failures of read-only methods are retried according to
+// options.Retry.
+//
+// The call goes through options.Interceptors, in order.
 func PerformCallContext(
 	ctx context.Context,
 	endpointURL string,
@@ -28,11 +32,139 @@ func PerformCallContext(
 	result interface{},
 	options *ClientOptions,
 ) error {
-	req, err := MakeRequest(endpointURL, params, options)
+	invoke := func(
+		ctx context.Context,
+		method string,
+		params interface{},
+		result interface{},
+	) error {
+		return performRetriedCall(
+			ctx,
+			replaceEndpointMethod(endpointURL, method),
+			params,
+			result,
+			options,
+		)
+	}
+
+	return chainInterceptors(options.Interceptors, invoke)(
+		ctx,
+		getEndpointMethod(endpointURL),
+		params,
+		result,
+	)
+}
+
+// performRetriedCall performs a call to the Conduit API, retrying it
+// according to options.Retry.
+func performRetriedCall(
+	ctx context.Context,
+	endpointURL string,
+	params interface{},
+	result interface{},
+	options *ClientOptions,
+) (err error) {
+	method := getEndpointMethod(endpointURL)
+
+	ctx, span := startSpan(ctx, endpointURL, options)
+
+	var a *callAttempt
+	retries := 0
+	defer func() { endSpan(span, a, retries, err) }()
+
+	for attempt := 1; ; attempt++ {
+		a, err = performLimitedCall(ctx, endpointURL, params, result, options)
+		if err == nil || !options.Retry.shouldRetry(ctx, method, attempt, err) {
+			return err
+		}
+
+		if serr := sleepContext(ctx, options.Retry.backoff(attempt)); serr != nil {
+			return fmt.Errorf("%w (last error: %v)", serr, err)
+		}
+
+		retries++
+	}
+}
+
+// performLimitedCall makes a single attempt of a call to the Conduit API once
+// options.RateLimiter allows it. The attempt is nil if it was not made.
+func performLimitedCall(
+	ctx context.Context,
+	endpointURL string,
+	params interface{},
+	result interface{},
+	options *ClientOptions,
+) (*callAttempt, error) {
+	release, err := options.RateLimiter.acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer release()
+
+	a := performCall(ctx, endpointURL, params, result, options)
+	options.RateLimiter.observe(a.err)
+
+	return a, a.err
+}
+
+// callAttempt describes a single HTTP round trip of a call to the Conduit API.
+type callAttempt struct {
+	method      string
+	params      interface{}
+	requestSize int64
+	status      int
+	body        []byte
+	duration    time.Duration
+	err         error
+}
+
+// performCall makes a single attempt of a call to the Conduit API.
+func performCall(
+	ctx context.Context,
+	endpointURL string,
+	params interface{},
+	result interface{},
+	options *ClientOptions,
+) *callAttempt {
+	a := &callAttempt{
+		method: getEndpointMethod(endpointURL),
+		params: params,
+	}
+	started := time.Now()
+
+	a.err = sendRequest(ctx, a, endpointURL, options)
+	if a.err == nil {
+		a.err = decodeResponse(a, result)
+	}
+	a.duration = time.Since(started)
+
+	logAttempt(ctx, options, a)
+	recordMetrics(options, a)
+
+	return a
+}
+
+// sendRequest sends the request of the attempt and reads the response.
+func sendRequest(
+	ctx context.Context,
+	a *callAttempt,
+	endpointURL string,
+	options *ClientOptions,
+) error {
+	options, err := withAccessToken(ctx, a.method, options)
+	if err != nil {
+		return err
+	}
+
+	req, err := MakeRequest(endpointURL, a.params, options)
 	if err != nil {
 		return err
 	}
 
+	a.requestSize = req.ContentLength
+
+	injectTraceContext(ctx, req, options)
+
 	client := makeHTTPClient(options)
 
 	resp, err := client.Do(req.WithContext(ctx))
@@ -41,24 +173,35 @@ func PerformCallContext(
 	}
 	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return err
-	}
+	a.status = resp.StatusCode
+
+	a.body, err = ioutil.ReadAll(resp.Body)
+
+	return err
+}
 
-	jsonBody, err := typed.Json(body)
+// decodeResponse unmarshals the result of the call from the response body,
+// or returns the error conduit responded with.
+func decodeResponse(a *callAttempt, result interface{}) error {
+	jsonBody, err := typed.Json(a.body)
 	if err != nil {
 		return &ConduitError{
-			code: strconv.Itoa(resp.StatusCode),
-			info: string(body),
+			code:       strconv.Itoa(a.status),
+			info:       string(a.body),
+			method:     a.method,
+			httpStatus: a.status,
+			body:       string(a.body),
 		}
 	}
 
 	// parse any error conduit returned first
 	if jsonBody.Exists("error_code") && jsonBody.String("error_code") != "" {
 		return &ConduitError{
-			code: jsonBody.String("error_code"),
-			info: jsonBody.String("error_info"),
+			code:       jsonBody.String("error_code"),
+			info:       jsonBody.String("error_info"),
+			method:     a.method,
+			httpStatus: a.status,
+			body:       string(a.body),
 		}
 	}
 
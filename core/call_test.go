@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strconv"
@@ -70,9 +71,14 @@ func TestPerformCall_withErrorCode(t *testing.T) {
 
 	code := strconv.Itoa(http.StatusNotFound)
 	assert.Equal(t, &ConduitError{
-		code: code,
-		info: "404 page not found",
+		code:       code,
+		info:       "404 page not found",
+		method:     "conduit.getcapabilities",
+		httpStatus: http.StatusNotFound,
+		body:       "404 page not found",
 	}, err)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.True(t, errors.Is(err, ErrHTTPStatus))
 }
 
 func TestPerformCall_withBadHTTPResponseCode(t *testing.T) {
@@ -95,10 +101,15 @@ func TestPerformCall_withBadHTTPResponseCode(t *testing.T) {
 		&ClientOptions{},
 	)
 
-	assert.Equal(t, &ConduitError{
-		code: "ERR-CONDUIT-CORE",
-		info: "Something bad happened",
-	}, err)
+	conduitErr, ok := err.(*ConduitError)
+	if assert.True(t, ok) {
+		assert.Equal(t, "ERR-CONDUIT-CORE", conduitErr.Code())
+		assert.Equal(t, "Something bad happened", conduitErr.Info())
+		assert.Equal(t, "return.error", conduitErr.Method())
+		assert.Equal(t, http.StatusOK, conduitErr.HTTPStatus())
+		assert.JSONEq(t, response, conduitErr.Body())
+	}
+	assert.False(t, errors.Is(err, ErrHTTPStatus))
 }
 
 func TestPerformCall_withMissingResults(t *testing.T) {
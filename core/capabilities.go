@@ -0,0 +1,28 @@
+package core
+
+// AuthScheme is an authentication scheme advertised by
+// conduit.getcapabilities.
+type AuthScheme string
+
+const (
+	// AuthSchemeNone is used when no credentials are configured.
+	AuthSchemeNone AuthScheme = ""
+	// AuthSchemeToken authenticates every call with an API token.
+	AuthSchemeToken AuthScheme = "token"
+	// AuthSchemeOAuth authenticates every call with an OAuth access token.
+	AuthSchemeOAuth AuthScheme = "oauth"
+	// AuthSchemeSession authenticates calls with a session key obtained from
+	// conduit.connect using a certificate.
+	AuthSchemeSession AuthScheme = "session"
+)
+
+// Encoding is an input or output encoding advertised by
+// conduit.getcapabilities.
+type Encoding string
+
+const (
+	// EncodingURLEncoded sends params as a urlencoded form.
+	EncodingURLEncoded Encoding = "urlencoded"
+	// EncodingJSON receives JSON documents.
+	EncodingJSON Encoding = "json"
+)
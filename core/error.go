@@ -1,6 +1,10 @@
 package core
 
-import "errors"
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
 
 var (
 	// ErrJSONOutputUnsupported is returned when conduit doesn't support JSON
@@ -30,13 +34,127 @@ var (
 	ErrTokenAuthUnsupported = errors.New(
 		"Token authentication is not supported",
 	)
+
+	// ErrNoHost is returned when no Phabricator host is configured in the
+	// environment, .arcconfig or .arcrc.
+	ErrNoHost = errors.New("no Phabricator host is configured")
+
+	// ErrNoCredentials is returned when no credentials are configured for
+	// the Phabricator host in the environment or .arcrc.
+	ErrNoCredentials = errors.New("no credentials are configured for host")
+
+	// ErrOAuthUnsupported is returned when conduit doesn't support OAuth
+	// access token authentication.
+	ErrOAuthUnsupported = errors.New(
+		"OAuth authentication is not supported",
+	)
 )
 
+// The following errors classify ConduitError values by cause. They are never
+// returned directly; use errors.Is to check whether a returned error belongs
+// to one of these classes:
+//
+//	if errors.Is(err, core.ErrInvalidSession) {
+//		// reconnect
+//	}
+var (
+	// ErrInvalidSession matches errors caused by an expired or unknown
+	// session key.
+	ErrInvalidSession = errors.New("invalid session")
+
+	// ErrInvalidAuth matches errors caused by invalid credentials, such as
+	// an unknown API token or a bad certificate.
+	ErrInvalidAuth = errors.New("invalid authentication")
+
+	// ErrPermissionDenied matches errors caused by the user lacking
+	// permission to perform the call.
+	ErrPermissionDenied = errors.New("permission denied")
+
+	// ErrNotFound matches errors caused by a missing method or object.
+	ErrNotFound = errors.New("not found")
+
+	// ErrRateLimited matches errors caused by the server rate limiting the
+	// client.
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrInvalidParameter matches errors caused by invalid call parameters.
+	ErrInvalidParameter = errors.New("invalid parameter")
+
+	// ErrHTTPStatus matches errors of calls which got a non-200 HTTP
+	// response.
+	ErrHTTPStatus = errors.New("unexpected HTTP status")
+)
+
+// conduitErrorKinds maps conduit error codes to the error class they belong
+// to. It is guarded by conduitErrorKindsMu, since RegisterConduitErrorKind may
+// extend it while errors are being classified.
+var (
+	conduitErrorKindsMu sync.RWMutex
+	conduitErrorKinds   = map[string]error{
+		"ERR-INVALID-SESSION":     ErrInvalidSession,
+		"ERR-INVALID-AUTH":        ErrInvalidAuth,
+		"ERR-INVALID-USER":        ErrInvalidAuth,
+		"ERR-INVALID-CERTIFICATE": ErrInvalidAuth,
+		"ERR-NO-CERTIFICATE":      ErrInvalidAuth,
+		"ERR-INVALID-TOKEN":       ErrInvalidAuth,
+		"ERR-PERMISSIONS":         ErrPermissionDenied,
+		"ERR-PERMISSION-DENIED":   ErrPermissionDenied,
+		"ERR-NOT-FOUND":           ErrNotFound,
+		"ERR_NOT_FOUND":           ErrNotFound,
+		"ERR-BAD-PHID":            ErrNotFound,
+		"ERR-BAD-DOCUMENT":        ErrNotFound,
+		"ERR_BAD_REVISION":        ErrNotFound,
+		"ERR_BAD_DIFF":            ErrNotFound,
+		"ERR_BAD_TASK":            ErrNotFound,
+		"ERR_BAD_PASTE":           ErrNotFound,
+		"ERR-RATE-LIMIT":          ErrRateLimited,
+		"ERR-INVALID-PARAMETER":   ErrInvalidParameter,
+		"ERR-BAD-PARAMETER":       ErrInvalidParameter,
+	}
+)
+
+// RegisterConduitErrorKind classifies the conduit error code as belonging to
+// the kind error class, so that errors.Is(err, kind) matches ConduitError
+// values with that code. It allows classifying the codes of extensions
+// installed on the server, and is safe for concurrent use.
+func RegisterConduitErrorKind(code string, kind error) {
+	conduitErrorKindsMu.Lock()
+	defer conduitErrorKindsMu.Unlock()
+
+	conduitErrorKinds[code] = kind
+}
+
+// conduitErrorKind returns the error class the conduit error code belongs
+// to.
+func conduitErrorKind(code string) (error, bool) {
+	conduitErrorKindsMu.RLock()
+	defer conduitErrorKindsMu.RUnlock()
+
+	kind, ok := conduitErrorKinds[code]
+
+	return kind, ok
+}
+
+// httpStatusErrorKinds maps HTTP status codes to the error class they belong
+// to.
+var httpStatusErrorKinds = map[int]error{
+	http.StatusUnauthorized:    ErrInvalidAuth,
+	http.StatusForbidden:       ErrPermissionDenied,
+	http.StatusNotFound:        ErrNotFound,
+	http.StatusTooManyRequests: ErrRateLimited,
+}
+
 // ConduitError is returned when conduit
 // requests return an error response.
+//
+// When the response is not a JSON conduit response, the code is the HTTP
+// status code and the info is the response body.
 type ConduitError struct {
-	code string
-	info string
+	code       string
+	info       string
+	method     string
+	httpStatus int
+	body       string
 }
 
 // Code returns the error_code returned in a conduit response.
@@ -49,13 +167,44 @@ func (err *ConduitError) Info() string {
 	return err.info
 }
 
+// Method returns the name of the conduit method which failed.
+func (err *ConduitError) Method() string {
+	return err.method
+}
+
+// HTTPStatus returns the status code of the HTTP response.
+func (err *ConduitError) HTTPStatus() int {
+	return err.httpStatus
+}
+
+// Body returns the raw body of the HTTP response.
+func (err *ConduitError) Body() string {
+	return err.body
+}
+
 func (err *ConduitError) Error() string {
 	return err.code + ": " + err.info
 }
 
-// IsConduitError checks whether or not err is a ConduitError.
+// Is reports whether the error belongs to the target error class, e.g.
+// ErrInvalidSession. It allows classifying errors with errors.Is.
+func (err *ConduitError) Is(target error) bool {
+	if target == ErrHTTPStatus {
+		return err.httpStatus != 0 && err.httpStatus != http.StatusOK
+	}
+
+	if kind, ok := conduitErrorKind(err.code); ok && kind == target {
+		return true
+	}
+
+	kind, ok := httpStatusErrorKinds[err.httpStatus]
+
+	return ok && kind == target
+}
+
+// IsConduitError checks whether or not err is, or wraps, a ConduitError.
 func IsConduitError(err error) bool {
-	_, ok := err.(*ConduitError)
+	var conduitErr *ConduitError
 
-	return ok
+	return errors.As(err, &conduitErr)
 }
@@ -1,7 +1,9 @@
 package core
 
 import (
+	"errors"
 	"fmt"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -45,4 +47,117 @@ func TestIsConduitError(t *testing.T) {
 	assert.True(t, IsConduitError(error(&err)))
 	assert.False(t, IsConduitError(err2))
 	assert.False(t, IsConduitError(err3))
+	assert.True(t, IsConduitError(fmt.Errorf("wrapped: %w", &err)))
+}
+
+func TestConduitErrorAccessors(t *testing.T) {
+	err := ConduitError{
+		code:       "ERR-CONDUIT-CORE",
+		info:       "OMG WHY",
+		method:     "maniphest.search",
+		httpStatus: 200,
+		body:       `{"error_code":"ERR-CONDUIT-CORE","error_info":"OMG WHY"}`,
+	}
+
+	assert.Equal(t, "maniphest.search", err.Method())
+	assert.Equal(t, 200, err.HTTPStatus())
+	assert.Equal(t, `{"error_code":"ERR-CONDUIT-CORE","error_info":"OMG WHY"}`, err.Body())
+}
+
+func TestConduitErrorIs(t *testing.T) {
+	tests := map[string]struct {
+		err  *ConduitError
+		want error
+	}{
+		"invalid_session": {
+			err:  &ConduitError{code: "ERR-INVALID-SESSION", httpStatus: 200},
+			want: ErrInvalidSession,
+		},
+		"invalid_auth": {
+			err:  &ConduitError{code: "ERR-INVALID-AUTH", httpStatus: 200},
+			want: ErrInvalidAuth,
+		},
+		"unauthorized": {
+			err:  &ConduitError{code: "401", httpStatus: 401},
+			want: ErrInvalidAuth,
+		},
+		"forbidden": {
+			err:  &ConduitError{code: "403", httpStatus: 403},
+			want: ErrPermissionDenied,
+		},
+		"not_found": {
+			err:  &ConduitError{code: "ERR_BAD_REVISION", httpStatus: 200},
+			want: ErrNotFound,
+		},
+		"rate_limit": {
+			err:  &ConduitError{code: "ERR-RATE-LIMIT", httpStatus: 200},
+			want: ErrRateLimited,
+		},
+		"too_many_requests": {
+			err:  &ConduitError{code: "429", httpStatus: 429},
+			want: ErrRateLimited,
+		},
+		"invalid_parameter": {
+			err:  &ConduitError{code: "ERR-INVALID-PARAMETER", httpStatus: 200},
+			want: ErrInvalidParameter,
+		},
+		"bad_gateway": {
+			err:  &ConduitError{code: "502", httpStatus: 502},
+			want: ErrHTTPStatus,
+		},
+	}
+
+	kinds := []error{
+		ErrInvalidSession,
+		ErrInvalidAuth,
+		ErrPermissionDenied,
+		ErrNotFound,
+		ErrRateLimited,
+		ErrInvalidParameter,
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			wrapped := fmt.Errorf("call failed: %w", test.err)
+			assert.True(t, errors.Is(wrapped, test.want))
+			for _, kind := range kinds {
+				if kind != test.want {
+					assert.False(t, errors.Is(wrapped, kind), kind.Error())
+				}
+			}
+
+			var conduitErr *ConduitError
+			assert.True(t, errors.As(wrapped, &conduitErr))
+			assert.Equal(t, test.err, conduitErr)
+		})
+	}
+}
+
+func TestRegisterConduitErrorKind(t *testing.T) {
+	const code = "ERR-EXTENSION-NOT-FOUND"
+	defer func() {
+		conduitErrorKindsMu.Lock()
+		delete(conduitErrorKinds, code)
+		conduitErrorKindsMu.Unlock()
+	}()
+
+	err := &ConduitError{code: code, httpStatus: 200}
+	assert.False(t, errors.Is(err, ErrNotFound))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			RegisterConduitErrorKind(code, ErrNotFound)
+		}()
+		go func() {
+			defer wg.Done()
+			errors.Is(err, ErrNotFound)
+		}()
+	}
+	wg.Wait()
+
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrPermissionDenied))
 }
@@ -2,7 +2,9 @@ package core
 
 import (
 	"crypto/tls"
+	"crypto/x509"
 	"net/http"
+	"net/url"
 	"time"
 )
 
@@ -16,6 +18,12 @@ type Client interface {
 type ClientOptions struct {
 	APIToken string
 
+	// AccessToken is an OAuth access token issued by Phabricator's OAuth
+	// server. If TokenSource is set, it is used instead to get a token for
+	// every attempt of a call, which allows refreshing expired tokens.
+	AccessToken string
+	TokenSource TokenSource
+
 	Cert       string
 	CertUser   string
 	SessionKey string
@@ -23,24 +31,90 @@ type ClientOptions struct {
 	InsecureSkipVerify bool
 	Timeout            time.Duration
 
+	// If set, failed calls to read-only methods are retried according to
+	// the policy. Calls are not retried otherwise.
+	Retry *RetryPolicy
+
+	// If set, calls wait for the limiter before being sent. The limiter is
+	// shared by every call made with these options.
+	RateLimiter *RateLimiter
+
+	// Interceptors wrap every call made with these options, including the
+	// ones made by Dial and Connect. The first interceptor is the outermost.
+	Interceptors []Interceptor
+
+	// If set, every HTTP round trip is logged to Logger, with the detail
+	// selected by LogVerbosity. Credentials are redacted from logged bodies,
+	// which are truncated to LogBodyLimit bytes (DefaultLogBodyLimit if
+	// zero, unlimited if negative).
+	Logger       Logger
+	LogVerbosity LogVerbosity
+	LogBodyLimit int
+
+	// If set, every call made with these options is traced with a span,
+	// and the trace context is propagated on the outgoing HTTP requests.
+	Tracer Tracer
+
+	// If set, the latency, HTTP status, conduit error code and payload
+	// sizes of every HTTP round trip are recorded to Metrics.
+	Metrics MetricsSink
+
 	// If set, Client will be used to execute HTTP requests.
 	// Otherwise, one is created with default settings and
-	// InsecureSkipVerify respected.
+	// InsecureSkipVerify respected. Dial creates a single client which is
+	// reused by every call made through the connection.
 	Client Client
-}
 
-// makeHttpClient creates a new HTTP client for making API requests.
-func makeHTTPClient(options *ClientOptions) Client {
-	if options.Client != nil {
-		return options.Client
-	}
+	// The following settings tune the transport of the client created when
+	// Client is not set. Zero values use the net/http defaults.
+
+	// MaxIdleConns limits the number of idle keep-alive connections.
+	MaxIdleConns int
+	// MaxIdleConnsPerHost limits the number of idle keep-alive connections
+	// to the Phabricator host.
+	MaxIdleConnsPerHost int
+	// IdleConnTimeout is how long an idle connection is kept open.
+	IdleConnTimeout time.Duration
+	// ForceAttemptHTTP2 enables HTTP/2 despite the custom TLS configuration.
+	ForceAttemptHTTP2 bool
+	// Proxy returns the proxy to use for a request, e.g.
+	// http.ProxyFromEnvironment. No proxy is used if nil.
+	Proxy func(*http.Request) (*url.URL, error)
+	// RootCAs are the certificate authorities used to verify the server.
+	// The system pool is used if nil.
+	RootCAs *x509.CertPool
+	// Certificates are presented to servers requiring client certificates.
+	Certificates []tls.Certificate
+}
 
+// NewHTTPClient creates an HTTP client configured by the options. The client
+// is safe for concurrent use and should be reused across calls, so that
+// connections are kept alive instead of being established (and TLS
+// handshakes performed) for every call.
+func NewHTTPClient(options *ClientOptions) *http.Client {
 	return &http.Client{
 		Timeout: options.Timeout,
 		Transport: &http.Transport{
+			Proxy: options.Proxy,
 			TLSClientConfig: &tls.Config{
 				InsecureSkipVerify: options.InsecureSkipVerify,
+				RootCAs:            options.RootCAs,
+				Certificates:       options.Certificates,
 			},
+			MaxIdleConns:        options.MaxIdleConns,
+			MaxIdleConnsPerHost: options.MaxIdleConnsPerHost,
+			IdleConnTimeout:     options.IdleConnTimeout,
+			ForceAttemptHTTP2:   options.ForceAttemptHTTP2,
 		},
 	}
 }
+
+// makeHttpClient returns the HTTP client for making API requests, creating a
+// new one if options do not provide it.
+func makeHTTPClient(options *ClientOptions) Client {
+	if options.Client != nil {
+		return options.Client
+	}
+
+	return NewHTTPClient(options)
+}
@@ -1,7 +1,11 @@
 package core
 
 import (
+	"crypto/x509"
+	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -24,3 +28,68 @@ func TestMakeHTTPClient(t *testing.T) {
 		Client: client,
 	}))
 }
+
+func TestNewHTTPClient(t *testing.T) {
+	pool := x509.NewCertPool()
+	client := NewHTTPClient(&ClientOptions{
+		Timeout:             time.Second,
+		InsecureSkipVerify:  true,
+		RootCAs:             pool,
+		MaxIdleConnsPerHost: 16,
+		IdleConnTimeout:     time.Minute,
+		ForceAttemptHTTP2:   true,
+		Proxy:               http.ProxyFromEnvironment,
+	})
+
+	assert.Equal(t, time.Second, client.Timeout)
+
+	transport, ok := client.Transport.(*http.Transport)
+	if assert.True(t, ok) {
+		assert.True(t, transport.TLSClientConfig.InsecureSkipVerify)
+		assert.Equal(t, pool, transport.TLSClientConfig.RootCAs)
+		assert.Equal(t, 16, transport.MaxIdleConnsPerHost)
+		assert.Equal(t, time.Minute, transport.IdleConnTimeout)
+		assert.True(t, transport.ForceAttemptHTTP2)
+		assert.NotNil(t, transport.Proxy)
+	}
+}
+
+func benchmarkPerformCall(b *testing.B, options *ClientOptions) {
+	ts := httptest.NewTLSServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			w.Write([]byte(`{"result":{}}`))
+		}))
+	defer ts.Close()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		err := PerformCall(
+			ts.URL+"/api/conduit.getcapabilities",
+			nil,
+			&map[string]interface{}{},
+			options,
+		)
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkPerformCall_withNewClient measures calls which create a new client,
+// and hence a new connection and TLS handshake, every time.
+func BenchmarkPerformCall_withNewClient(b *testing.B) {
+	benchmarkPerformCall(b, &ClientOptions{
+		InsecureSkipVerify: true,
+	})
+}
+
+// BenchmarkPerformCall_withSharedClient measures calls which reuse a client
+// and its keep-alive connections, as done by Conn.
+func BenchmarkPerformCall_withSharedClient(b *testing.B) {
+	options := &ClientOptions{
+		InsecureSkipVerify: true,
+	}
+	options.Client = NewHTTPClient(options)
+
+	benchmarkPerformCall(b, options)
+}
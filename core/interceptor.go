@@ -0,0 +1,92 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// Invoker performs a conduit call to method with the given params, decoding
+// the response into result.
+type Invoker func(
+	ctx context.Context,
+	method string,
+	params interface{},
+	result interface{},
+) error
+
+// Interceptor wraps conduit calls made with ClientOptions. It is given the
+// call and the next Invoker in the chain, which it must call to proceed
+// with the call. Interceptors may inspect or replace the params before the
+// call, and inspect the result and error after it, which allows adding
+// logging, metrics or tracing, or mutating requests.
+//
+// Interceptors are run once per call, around any retries.
+type Interceptor func(
+	ctx context.Context,
+	method string,
+	params interface{},
+	result interface{},
+	next Invoker,
+) error
+
+// CallInfo describes a completed conduit call.
+type CallInfo struct {
+	Method   string
+	Params   interface{}
+	Result   interface{}
+	Err      error
+	Duration time.Duration
+}
+
+// ObserverInterceptor returns an interceptor which calls fn after every call
+// with the description of the call.
+func ObserverInterceptor(
+	fn func(ctx context.Context, info CallInfo),
+) Interceptor {
+	return func(
+		ctx context.Context,
+		method string,
+		params interface{},
+		result interface{},
+		next Invoker,
+	) error {
+		started := time.Now()
+		err := next(ctx, method, params, result)
+
+		fn(ctx, CallInfo{
+			Method:   method,
+			Params:   params,
+			Result:   result,
+			Err:      err,
+			Duration: time.Since(started),
+		})
+
+		return err
+	}
+}
+
+// chainInterceptors wraps the invoker with the interceptors. The first
+// interceptor is the outermost one, so it is the first to see the call.
+func chainInterceptors(interceptors []Interceptor, invoker Invoker) Invoker {
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], invoker
+		invoker = func(
+			ctx context.Context,
+			method string,
+			params interface{},
+			result interface{},
+		) error {
+			return interceptor(ctx, method, params, result, next)
+		}
+	}
+
+	return invoker
+}
+
+// replaceEndpointMethod returns the endpoint URI of method on the same host as
+// the given endpoint URI.
+func replaceEndpointMethod(endpointURL string, method string) string {
+	return strings.TrimSuffix(endpointURL, getEndpointMethod(endpointURL)) +
+		method
+}
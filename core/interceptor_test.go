@@ -0,0 +1,151 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/test/server"
+)
+
+func TestPerformCallContext_withInterceptorsInOrder(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+	ts.RegisterCapabilities()
+
+	var calls []string
+	interceptor := func(name string) Interceptor {
+		return func(
+			ctx context.Context,
+			method string,
+			params interface{},
+			result interface{},
+			next Invoker,
+		) error {
+			calls = append(calls, name+" before "+method)
+			err := next(ctx, method, params, result)
+			calls = append(calls, name+" after "+method)
+			return err
+		}
+	}
+
+	result := map[string]interface{}{}
+	err := PerformCall(
+		ts.GetURL()+"/api/conduit.getcapabilities",
+		map[string]interface{}{},
+		&result,
+		&ClientOptions{
+			Interceptors: []Interceptor{interceptor("a"), interceptor("b")},
+		},
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{
+		"a before conduit.getcapabilities",
+		"b before conduit.getcapabilities",
+		"b after conduit.getcapabilities",
+		"a after conduit.getcapabilities",
+	}, calls)
+}
+
+func TestPerformCallContext_withInterceptorMutatingParams(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+
+	var received map[string]interface{}
+	ts.RegisterMethodFunc("phid.lookup", func(
+		params map[string]interface{},
+	) (int, map[string]interface{}) {
+		received = params
+		return 200, server.ResponseFromJSON(`{"result":{}}`)
+	})
+
+	addNames := func(
+		ctx context.Context,
+		method string,
+		params interface{},
+		result interface{},
+		next Invoker,
+	) error {
+		params = map[string]interface{}{"names": []string{"T1"}}
+		return next(ctx, method, params, result)
+	}
+
+	result := map[string]interface{}{}
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		map[string]interface{}{},
+		&result,
+		&ClientOptions{Interceptors: []Interceptor{addNames}},
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"T1"}, received["names"])
+}
+
+func TestPerformCallContext_withInterceptorShortCircuit(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+
+	denied := errors.New("denied")
+	deny := func(
+		ctx context.Context,
+		method string,
+		params interface{},
+		result interface{},
+		next Invoker,
+	) error {
+		return denied
+	}
+
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{Interceptors: []Interceptor{deny}},
+	)
+
+	assert.Equal(t, denied, err)
+}
+
+func TestObserverInterceptor(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+	ts.RegisterMethod("phid.lookup", 200, server.ResponseFromJSON(`{
+		"error_code": "ERR-CONDUIT-CORE",
+		"error_info": "Something went wrong."
+	}`))
+
+	var infos []CallInfo
+	observer := ObserverInterceptor(func(ctx context.Context, info CallInfo) {
+		infos = append(infos, info)
+	})
+
+	params := map[string]interface{}{"names": []string{"T1"}}
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		params,
+		&map[string]interface{}{},
+		&ClientOptions{Interceptors: []Interceptor{observer}},
+	)
+
+	assert.NotNil(t, err)
+	if assert.Len(t, infos, 1) {
+		assert.Equal(t, "phid.lookup", infos[0].Method)
+		assert.Equal(t, params, infos[0].Params)
+		assert.Equal(t, err, infos[0].Err)
+		assert.True(t, infos[0].Duration > 0)
+	}
+}
+
+func TestReplaceEndpointMethod(t *testing.T) {
+	assert.Equal(
+		t,
+		"https://phab.example.com/api/phid.query",
+		replaceEndpointMethod(
+			"https://phab.example.com/api/phid.lookup",
+			"phid.query",
+		),
+	)
+}
@@ -0,0 +1,169 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"unicode/utf8"
+)
+
+// LogLevel is the severity of a log entry. Its values match the levels of
+// log/slog.
+type LogLevel int
+
+const (
+	// LogLevelDebug is used for successful calls.
+	LogLevelDebug LogLevel = -4
+	// LogLevelInfo is the default slog level.
+	LogLevelInfo LogLevel = 0
+	// LogLevelWarn is used for failed calls.
+	LogLevelWarn LogLevel = 4
+	// LogLevelError is the slog error level.
+	LogLevelError LogLevel = 8
+)
+
+// Logger records conduit traffic. Its Log method has the same signature as
+// slog.Logger.Log, with keyvals being alternating keys and values.
+type Logger interface {
+	Log(ctx context.Context, level LogLevel, msg string, keyvals ...interface{})
+}
+
+// LoggerFunc is a function which implements Logger.
+type LoggerFunc func(
+	ctx context.Context,
+	level LogLevel,
+	msg string,
+	keyvals ...interface{},
+)
+
+// Log calls f.
+func (f LoggerFunc) Log(
+	ctx context.Context,
+	level LogLevel,
+	msg string,
+	keyvals ...interface{},
+) {
+	f(ctx, level, msg, keyvals...)
+}
+
+// LogVerbosity selects what is logged for each call.
+type LogVerbosity int
+
+const (
+	// LogCalls logs the method, HTTP status, response size, duration and
+	// error of each call.
+	LogCalls LogVerbosity = iota
+	// LogBodies additionally logs the request params and the response body,
+	// with credentials redacted.
+	LogBodies
+)
+
+// DefaultLogBodyLimit is the number of bytes of a body which are logged when
+// ClientOptions.LogBodyLimit is zero.
+const DefaultLogBodyLimit = 4096
+
+// redacted replaces the values of redactedKeys in logged bodies.
+const redacted = "[REDACTED]"
+
+// redactedKeys are the keys of credentials sent in the conduit metadata,
+// conduit.connect params and returned by conduit.connect.
+var redactedKeys = map[string]bool{
+	"token":          true,
+	"access_token":   true,
+	"sessionKey":     true,
+	"auth.signature": true,
+	"authSignature":  true,
+}
+
+// logAttempt records a single attempt of a call to options.Logger.
+func logAttempt(ctx context.Context, options *ClientOptions, a *callAttempt) {
+	if options.Logger == nil {
+		return
+	}
+
+	level, msg := LogLevelDebug, "conduit call"
+	if a.err != nil {
+		level, msg = LogLevelWarn, "conduit call failed"
+	}
+
+	keyvals := []interface{}{
+		"method", a.method,
+		"status", a.status,
+		"size", len(a.body),
+		"duration", a.duration,
+	}
+
+	if a.err != nil {
+		keyvals = append(keyvals, "error", a.err.Error())
+	}
+
+	if options.LogVerbosity >= LogBodies {
+		limit := options.LogBodyLimit
+		if limit == 0 {
+			limit = DefaultLogBodyLimit
+		}
+
+		params, err := json.Marshal(a.params)
+		if err != nil {
+			params = []byte(err.Error())
+		}
+
+		keyvals = append(
+			keyvals,
+			"params", truncateBody(redactBody(params), limit),
+			"response", truncateBody(redactBody(a.body), limit),
+		)
+	}
+
+	options.Logger.Log(ctx, level, msg, keyvals...)
+}
+
+// redactBody replaces credentials found in a JSON body. Bodies which are not
+// JSON are returned as is.
+func redactBody(body []byte) string {
+	var document interface{}
+	if err := json.Unmarshal(body, &document); err != nil {
+		return string(body)
+	}
+
+	redactedBody, err := json.Marshal(redactValue(document))
+	if err != nil {
+		return string(body)
+	}
+
+	return string(redactedBody)
+}
+
+func redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for key, child := range v {
+			if redactedKeys[key] {
+				v[key] = redacted
+			} else {
+				v[key] = redactValue(child)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child)
+		}
+	}
+
+	return value
+}
+
+// truncateBody shortens body to at most limit bytes, without splitting a
+// UTF-8 character. A negative limit disables truncation.
+func truncateBody(body string, limit int) string {
+	if limit < 0 || len(body) <= limit {
+		return body
+	}
+
+	end := limit
+	for end > 0 && !utf8.RuneStart(body[end]) {
+		end--
+	}
+
+	return fmt.Sprintf("%s...[%d bytes truncated]", body[:end], len(body)-end)
+}
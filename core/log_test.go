@@ -0,0 +1,154 @@
+package core
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/requests"
+	"github.com/uber/gonduit/test/server"
+)
+
+type logEntry struct {
+	level  LogLevel
+	msg    string
+	fields map[string]interface{}
+}
+
+func recordLogs(entries *[]logEntry) Logger {
+	return LoggerFunc(func(
+		ctx context.Context,
+		level LogLevel,
+		msg string,
+		keyvals ...interface{},
+	) {
+		fields := map[string]interface{}{}
+		for i := 0; i+1 < len(keyvals); i += 2 {
+			fields[keyvals[i].(string)] = keyvals[i+1]
+		}
+
+		*entries = append(*entries, logEntry{level, msg, fields})
+	})
+}
+
+func TestPerformCall_withLogger(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+	ts.RegisterMethod("phid.lookup", 200, server.ResponseFromJSON(
+		`{"result":{}}`,
+	))
+
+	var entries []logEntry
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		&requests.PHIDLookupRequest{Names: []string{"T1"}},
+		&map[string]interface{}{},
+		&ClientOptions{
+			APIToken: "api-secret",
+			Logger:   recordLogs(&entries),
+		},
+	)
+
+	assert.Nil(t, err)
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, LogLevelDebug, entries[0].level)
+		assert.Equal(t, "conduit call", entries[0].msg)
+		assert.Equal(t, "phid.lookup", entries[0].fields["method"])
+		assert.Equal(t, 200, entries[0].fields["status"])
+		assert.NotContains(t, entries[0].fields, "params")
+		assert.NotContains(t, entries[0].fields, "response")
+	}
+}
+
+func TestPerformCall_withLoggerRedactsCredentials(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+	ts.RegisterMethod("phid.lookup", 200, server.ResponseFromJSON(`{
+		"error_code": "ERR-INVALID-AUTH",
+		"error_info": "API token is invalid."
+	}`))
+	ts.RegisterMethod("conduit.connect", 200, server.ResponseFromJSON(`{
+		"result": {"connectionID": 1, "sessionKey": "session-secret"}
+	}`))
+
+	var entries []logEntry
+	options := &ClientOptions{
+		APIToken:     "api-secret",
+		Logger:       recordLogs(&entries),
+		LogVerbosity: LogBodies,
+	}
+
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		&requests.PHIDLookupRequest{Names: []string{"T1"}},
+		&map[string]interface{}{},
+		options,
+	)
+	assert.NotNil(t, err)
+
+	err = PerformCall(
+		ts.GetURL()+"/api/conduit.connect",
+		&requests.ConduitConnectRequest{AuthSignature: "signature-secret"},
+		&map[string]interface{}{},
+		options,
+	)
+	assert.Nil(t, err)
+
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, LogLevelWarn, entries[0].level)
+		assert.Equal(t, "conduit call failed", entries[0].msg)
+		assert.Contains(t, entries[0].fields["error"], "ERR-INVALID-AUTH")
+		assert.Contains(t, entries[0].fields["params"], `"names":["T1"]`)
+		assert.Contains(t, entries[0].fields["response"], "ERR-INVALID-AUTH")
+	}
+
+	for _, entry := range entries {
+		for _, key := range []string{"params", "response"} {
+			body := entry.fields[key].(string)
+			assert.NotContains(t, body, "secret")
+		}
+	}
+}
+
+func TestPerformCall_withLoggerTruncatesBodies(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+	ts.RegisterMethod("phid.lookup", 200, server.ResponseFromJSON(
+		`{"result":{"T1":{"fullName":"`+strings.Repeat("x", 100)+`"}}}`,
+	))
+
+	var entries []logEntry
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			Logger:       recordLogs(&entries),
+			LogVerbosity: LogBodies,
+			LogBodyLimit: 16,
+		},
+	)
+
+	assert.Nil(t, err)
+	if assert.Len(t, entries, 1) {
+		response := entries[0].fields["response"].(string)
+		assert.True(t, strings.HasSuffix(response, " bytes truncated]"))
+		assert.True(t, len(response) < 50)
+	}
+}
+
+func TestTruncateBody(t *testing.T) {
+	assert.Equal(t, "abc", truncateBody("abc", 3))
+	assert.Equal(t, "abc", truncateBody("abc", -1))
+	assert.Equal(t, "a...[2 bytes truncated]", truncateBody("aé", 2))
+}
+
+func TestRedactBody(t *testing.T) {
+	assert.Equal(
+		t,
+		`{"__conduit__":{"token":"[REDACTED]"},"ids":[1]}`,
+		redactBody([]byte(`{"__conduit__":{"token":"api-secret"},"ids":[1]}`)),
+	)
+	assert.Equal(t, "<html>", redactBody([]byte("<html>")))
+}
@@ -0,0 +1,149 @@
+package core
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CallMetrics describes a single HTTP round trip of a conduit call. Retried
+// calls are recorded once per attempt.
+type CallMetrics struct {
+	Method   string
+	Duration time.Duration
+	// HTTPStatus is zero if no response was received.
+	HTTPStatus int
+	// ErrorCode is the conduit error code, or the HTTP status for
+	// responses which are not JSON. It is empty for successful calls and
+	// errors which are not ConduitErrors.
+	ErrorCode    string
+	RequestSize  int64
+	ResponseSize int64
+	Err          error
+}
+
+// MetricsSink receives the metrics of conduit calls. It must be safe for
+// concurrent use.
+type MetricsSink interface {
+	RecordCall(metrics CallMetrics)
+}
+
+// recordMetrics sends the metrics of the attempt to options.Metrics.
+func recordMetrics(options *ClientOptions, a *callAttempt) {
+	if options.Metrics == nil {
+		return
+	}
+
+	metrics := CallMetrics{
+		Method:       a.method,
+		Duration:     a.duration,
+		HTTPStatus:   a.status,
+		RequestSize:  a.requestSize,
+		ResponseSize: int64(len(a.body)),
+		Err:          a.err,
+	}
+
+	var conduitErr *ConduitError
+	if errors.As(a.err, &conduitErr) {
+		metrics.ErrorCode = conduitErr.Code()
+	}
+
+	options.Metrics.RecordCall(metrics)
+}
+
+// MethodMetrics are the metrics aggregated by MemoryMetrics for a method.
+type MethodMetrics struct {
+	Calls         int
+	Errors        int
+	Latencies     []time.Duration
+	ErrorCodes    map[string]int
+	HTTPStatuses  map[int]int
+	RequestBytes  int64
+	ResponseBytes int64
+}
+
+// MemoryMetrics is a MetricsSink which aggregates metrics in memory. It is
+// mostly useful in tests.
+type MemoryMetrics struct {
+	mu      sync.Mutex
+	methods map[string]*MethodMetrics
+}
+
+// NewMemoryMetrics creates an empty MemoryMetrics.
+func NewMemoryMetrics() *MemoryMetrics {
+	return &MemoryMetrics{methods: map[string]*MethodMetrics{}}
+}
+
+// RecordCall implements MetricsSink.
+func (m *MemoryMetrics) RecordCall(metrics CallMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	method, ok := m.methods[metrics.Method]
+	if !ok {
+		method = &MethodMetrics{
+			ErrorCodes:   map[string]int{},
+			HTTPStatuses: map[int]int{},
+		}
+		m.methods[metrics.Method] = method
+	}
+
+	method.Calls++
+	method.Latencies = append(method.Latencies, metrics.Duration)
+	method.RequestBytes += metrics.RequestSize
+	method.ResponseBytes += metrics.ResponseSize
+
+	if metrics.Err != nil {
+		method.Errors++
+	}
+
+	if metrics.ErrorCode != "" {
+		method.ErrorCodes[metrics.ErrorCode]++
+	}
+
+	if metrics.HTTPStatus != 0 {
+		method.HTTPStatuses[metrics.HTTPStatus]++
+	}
+}
+
+// Methods returns the sorted names of the methods which were called.
+func (m *MemoryMetrics) Methods() []string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	methods := make([]string, 0, len(m.methods))
+	for method := range m.methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+
+	return methods
+}
+
+// Method returns a copy of the metrics of a method.
+func (m *MemoryMetrics) Method(name string) MethodMetrics {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	method, ok := m.methods[name]
+	if !ok {
+		return MethodMetrics{
+			ErrorCodes:   map[string]int{},
+			HTTPStatuses: map[int]int{},
+		}
+	}
+
+	snapshot := *method
+	snapshot.Latencies = append([]time.Duration(nil), method.Latencies...)
+	snapshot.ErrorCodes = map[string]int{}
+	for code, count := range method.ErrorCodes {
+		snapshot.ErrorCodes[code] = count
+	}
+	snapshot.HTTPStatuses = map[int]int{}
+	for status, count := range method.HTTPStatuses {
+		snapshot.HTTPStatuses[status] = count
+	}
+
+	return snapshot
+}
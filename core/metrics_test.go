@@ -0,0 +1,73 @@
+package core
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/test/server"
+)
+
+func TestPerformCall_withMetrics(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+	ts.RegisterCapabilities()
+	ts.RegisterMethod("phid.lookup", 503, server.ResponseFromJSON(`{
+		"error_code": "ERR-CONDUIT-CORE",
+		"error_info": "Service unavailable."
+	}`))
+
+	metrics := NewMemoryMetrics()
+	options := &ClientOptions{
+		Metrics: metrics,
+		Retry: &RetryPolicy{
+			MaxAttempts:    2,
+			InitialBackoff: time.Millisecond,
+		},
+	}
+
+	err := PerformCall(
+		ts.GetURL()+"/api/conduit.getcapabilities",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		options,
+	)
+	assert.Nil(t, err)
+
+	err = PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		map[string]interface{}{"names": []string{"T1"}},
+		&map[string]interface{}{},
+		options,
+	)
+	assert.NotNil(t, err)
+
+	assert.Equal(
+		t,
+		[]string{"conduit.getcapabilities", "phid.lookup"},
+		metrics.Methods(),
+	)
+
+	capabilities := metrics.Method("conduit.getcapabilities")
+	assert.Equal(t, 1, capabilities.Calls)
+	assert.Equal(t, 0, capabilities.Errors)
+	assert.Len(t, capabilities.Latencies, 1)
+	assert.Equal(t, map[int]int{200: 1}, capabilities.HTTPStatuses)
+	assert.Empty(t, capabilities.ErrorCodes)
+	assert.True(t, capabilities.RequestBytes > 0)
+	assert.True(t, capabilities.ResponseBytes > 0)
+
+	// The failed call was retried once.
+	lookup := metrics.Method("phid.lookup")
+	assert.Equal(t, 2, lookup.Calls)
+	assert.Equal(t, 2, lookup.Errors)
+	assert.Equal(t, map[int]int{503: 2}, lookup.HTTPStatuses)
+	assert.Equal(t, map[string]int{"ERR-CONDUIT-CORE": 2}, lookup.ErrorCodes)
+}
+
+func TestMemoryMetrics_withUnknownMethod(t *testing.T) {
+	metrics := NewMemoryMetrics()
+
+	assert.Equal(t, 0, metrics.Method("phid.lookup").Calls)
+	assert.Empty(t, metrics.Methods())
+}
@@ -0,0 +1,156 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+)
+
+const (
+	// rateLimiterMinRateFraction is the lowest fraction of the configured rate
+	// a RateLimiter slows down to when the server keeps rate limiting calls.
+	rateLimiterMinRateFraction = 1.0 / 32
+	// rateLimiterRecoveryFraction is the fraction of the configured rate
+	// which is restored after every successful call.
+	rateLimiterRecoveryFraction = 1.0 / 10
+)
+
+// RateLimiter limits the rate and the concurrency of conduit calls. Calls
+// made with the same ClientOptions, and hence through the same Conn, share the
+// limiter; a single limiter may also be shared by several ClientOptions.
+//
+// The rate is enforced with a token bucket. When the server reports that the
+// client was rate limited, the rate is halved and then gradually restored as
+// calls succeed again.
+type RateLimiter struct {
+	mu       sync.Mutex
+	maxRate  float64
+	rate     float64
+	burst    float64
+	tokens   float64
+	last     time.Time
+	inFlight chan struct{}
+}
+
+// NewRateLimiter creates a limiter which allows rate calls per second with
+// bursts of up to burst calls, and at most maxInFlight concurrent calls. A
+// non-positive rate or maxInFlight disables the corresponding limit.
+func NewRateLimiter(rate float64, burst int, maxInFlight int) *RateLimiter {
+	l := &RateLimiter{
+		maxRate: rate,
+		rate:    rate,
+		burst:   math.Max(float64(burst), 1),
+		last:    time.Now(),
+	}
+	l.tokens = l.burst
+
+	if maxInFlight > 0 {
+		l.inFlight = make(chan struct{}, maxInFlight)
+	}
+
+	return l
+}
+
+// Rate returns the current number of calls allowed per second, which is
+// lower than the configured rate while the limiter is slowed down.
+func (l *RateLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.rate
+}
+
+// acquire blocks until a call may be made or the context is done. The
+// returned function must be called once the call completes.
+func (l *RateLimiter) acquire(ctx context.Context) (func(), error) {
+	if l == nil {
+		return func() {}, nil
+	}
+
+	release := func() {}
+	if l.inFlight != nil {
+		select {
+		case l.inFlight <- struct{}{}:
+			release = func() { <-l.inFlight }
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := l.wait(ctx); err != nil {
+		release()
+		return nil, err
+	}
+
+	return release, nil
+}
+
+// wait takes a token from the bucket, waiting for one to become available.
+func (l *RateLimiter) wait(ctx context.Context) error {
+	l.mu.Lock()
+	if l.rate <= 0 {
+		l.mu.Unlock()
+		return nil
+	}
+
+	l.refill(time.Now())
+	// Tokens may go negative: every waiting caller reserves its own token.
+	l.tokens--
+	delay := time.Duration(-l.tokens / l.rate * float64(time.Second))
+	l.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+
+	if err := sleepContext(ctx, delay); err != nil {
+		l.mu.Lock()
+		l.tokens++
+		l.mu.Unlock()
+		return err
+	}
+
+	return nil
+}
+
+// refill adds the tokens accumulated since the last refill. It must be called
+// with mu held.
+func (l *RateLimiter) refill(now time.Time) {
+	elapsed := now.Sub(l.last).Seconds()
+	l.last = now
+	l.tokens = math.Min(l.tokens+elapsed*l.rate, l.burst)
+}
+
+// observe adjusts the rate to the outcome of a call: it is halved when the
+// server rate limited the call, and partially restored when the call
+// succeeded.
+func (l *RateLimiter) observe(err error) {
+	if l == nil || l.maxRate <= 0 {
+		return
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill(time.Now())
+
+	switch {
+	case isRateLimitError(err):
+		l.rate = math.Max(l.rate/2, l.maxRate*rateLimiterMinRateFraction)
+		// Drop the accumulated burst, so the next calls are spaced out.
+		l.tokens = math.Min(l.tokens, 0)
+	case err == nil:
+		l.rate = math.Min(
+			l.rate+l.maxRate*rateLimiterRecoveryFraction,
+			l.maxRate,
+		)
+	}
+}
+
+// isRateLimitError reports whether err signals that the server rate limited
+// the call, either with a conduit error code or an HTTP 429 response.
+func isRateLimitError(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
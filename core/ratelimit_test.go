@@ -0,0 +1,128 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimiter_withRate(t *testing.T) {
+	limiter := NewRateLimiter(100, 1, 0)
+
+	started := time.Now()
+	for i := 0; i < 5; i++ {
+		release, err := limiter.acquire(context.Background())
+		assert.Nil(t, err)
+		release()
+	}
+
+	// The first call uses the burst, the other four wait 10ms each.
+	assert.True(t, time.Since(started) >= 35*time.Millisecond)
+}
+
+func TestRateLimiter_withCancelledContext(t *testing.T) {
+	limiter := NewRateLimiter(1, 1, 0)
+
+	release, err := limiter.acquire(context.Background())
+	assert.Nil(t, err)
+	release()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = limiter.acquire(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRateLimiter_withMaxInFlight(t *testing.T) {
+	var current, max int32
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			n := atomic.AddInt32(&current, 1)
+			defer atomic.AddInt32(&current, -1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(5 * time.Millisecond)
+			w.Write([]byte(`{"result":{}}`))
+		}))
+	defer ts.Close()
+
+	options := &ClientOptions{
+		RateLimiter: NewRateLimiter(0, 0, 2),
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := PerformCall(
+				ts.URL+"/api/maniphest.search",
+				map[string]interface{}{},
+				&map[string]interface{}{},
+				options,
+			)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), max)
+}
+
+func TestRateLimiter_withServerRateLimit(t *testing.T) {
+	var calls int32
+	ts := httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				w.Write([]byte(`{
+					"result": null,
+					"error_code": "ERR-RATE-LIMIT",
+					"error_info": "You are making too many requests."
+				}`))
+				return
+			}
+			w.Write([]byte(`{"result":{}}`))
+		}))
+	defer ts.Close()
+
+	limiter := NewRateLimiter(1000, 10, 0)
+	options := &ClientOptions{
+		RateLimiter: limiter,
+	}
+
+	err := PerformCall(
+		ts.URL+"/api/maniphest.search",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		options,
+	)
+	assert.True(t, isRateLimitError(err))
+	assert.Equal(t, float64(500), limiter.Rate())
+
+	err = PerformCall(
+		ts.URL+"/api/maniphest.search",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		options,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(600), limiter.Rate())
+}
+
+func TestIsRateLimitError(t *testing.T) {
+	assert.True(t, isRateLimitError(&ConduitError{code: "ERR-RATE-LIMIT"}))
+	assert.True(t, isRateLimitError(&ConduitError{code: "429", httpStatus: 429}))
+	assert.False(t, isRateLimitError(&ConduitError{code: "ERR-CONDUIT-CORE"}))
+	assert.False(t, isRateLimitError(nil))
+}
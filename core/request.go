@@ -10,6 +10,10 @@ import (
 )
 
 // MakeRequest creates a new requests to the conduit API.
+//
+// The params are sent JSON encoded in the "params" field of a urlencoded
+// form, which is what conduit expects for both the "urlencoded" and "json"
+// input encodings it advertises.
 func MakeRequest(
 	endpointURL string,
 	params interface{},
@@ -49,11 +53,31 @@ func prepareForm(
 	form := url.Values{}
 	form.Add("output", "json")
 
+	params, err := prepareParams(body, options)
+	if err != nil {
+		return nil, err
+	}
+
+	form.Add("params", string(params))
+
+	handleConnectRequest(&form, body)
+
+	return form, nil
+}
+
+// prepareParams injects the conduit metadata into the request and encodes it
+// as JSON.
+func prepareParams(
+	body interface{},
+	options *ClientOptions,
+) ([]byte, error) {
 	if request, ok := body.(requests.RequestInterface); ok {
 		metadata := requests.ConduitMetadata{}
 
 		if options.APIToken != "" {
 			metadata.Token = options.APIToken
+		} else if options.AccessToken != "" {
+			metadata.AccessToken = options.AccessToken
 		} else if options.SessionKey != "" {
 			metadata.SessionKey = options.SessionKey
 		}
@@ -61,33 +85,21 @@ func prepareForm(
 		request.SetMetadata(&metadata)
 	}
 
-	if body != nil {
-		jsonBody, err := json.Marshal(body)
-
-		if err != nil {
-			return nil, err
-		}
-
-		form.Add("params", string(jsonBody))
-
-		handleConnectRequest(&form, body)
-	} else {
-		jsonBody, err := json.Marshal(map[string]interface{}{})
-
-		if err != nil {
-			return nil, err
-		}
-
-		form.Add("params", string(jsonBody))
+	if body == nil {
+		return json.Marshal(map[string]interface{}{})
 	}
 
-	return form, nil
+	return json.Marshal(body)
 }
 
 func handleConnectRequest(form *url.Values, body interface{}) {
-	_, isConduitConnect := body.(*requests.ConduitConnectRequest)
-
-	if isConduitConnect {
+	if isConnectRequest(body) {
 		form.Add("__conduit__", "true")
 	}
 }
+
+func isConnectRequest(body interface{}) bool {
+	_, isConduitConnect := body.(*requests.ConduitConnectRequest)
+
+	return isConduitConnect
+}
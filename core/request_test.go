@@ -128,6 +128,18 @@ func TestPrepareForm_withSessionKey(t *testing.T) {
 	assert.Equal(t, string(jsonBody), form.Get("params"))
 }
 
+func TestPrepareForm_withAccessToken(t *testing.T) {
+	form, err := prepareForm(&requests.Request{}, &ClientOptions{
+		AccessToken: "hello-world-hello-world",
+	})
+
+	jsonBody := `{"__conduit__":{"access_token":"hello-world-hello-world"}}`
+
+	assert.Nil(t, err)
+	assert.Equal(t, "json", form.Get("output"))
+	assert.Equal(t, jsonBody, form.Get("params"))
+}
+
 func TestPrepareForm_withError(t *testing.T) {
 	_, err := prepareForm(
 		http.Request{},
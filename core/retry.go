@@ -0,0 +1,185 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	defaultRetryInitialBackoff = 100 * time.Millisecond
+	defaultRetryMaxBackoff     = 5 * time.Second
+	defaultRetryMultiplier     = 2
+)
+
+// RetryPolicy configures how failed calls are retried by PerformCallContext.
+//
+// Only calls to read-only methods (see IsIdempotentMethod) are ever retried,
+// so that a mutation such as *.edit or *.create is never applied twice. If the
+// context is done while waiting for a retry, the returned error wraps the
+// context error and includes the error of the last attempt.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts, including the first one.
+	// Values below 2 disable retries.
+	MaxAttempts int
+
+	// InitialBackoff is the delay before the first retry. Defaults to 100ms.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts. Defaults to 5s.
+	MaxBackoff time.Duration
+
+	// Multiplier is the factor by which the delay grows after every retry.
+	// Defaults to 2.
+	Multiplier float64
+
+	// Jitter is the fraction of the delay, between 0 and 1, which is
+	// randomized to avoid synchronized retries from many clients. A jitter of
+	// 0.2 spreads a 1s delay over [0.8s, 1.2s].
+	Jitter float64
+
+	// Retryable reports whether a failed attempt should be retried. Defaults
+	// to IsTransientError.
+	Retryable func(err error) bool
+}
+
+// shouldRetry reports whether a call to method, which failed with err on the
+// given attempt, should be attempted again.
+func (p *RetryPolicy) shouldRetry(
+	ctx context.Context,
+	method string,
+	attempt int,
+	err error,
+) bool {
+	if p == nil || attempt >= p.MaxAttempts || ctx.Err() != nil {
+		return false
+	}
+
+	if !IsIdempotentMethod(method) {
+		return false
+	}
+
+	if p.Retryable != nil {
+		return p.Retryable(err)
+	}
+
+	return IsTransientError(err)
+}
+
+// backoff returns the delay to wait after the given failed attempt.
+func (p *RetryPolicy) backoff(attempt int) time.Duration {
+	initial, max, multiplier :=
+		p.InitialBackoff, p.MaxBackoff, p.Multiplier
+	if initial <= 0 {
+		initial = defaultRetryInitialBackoff
+	}
+	if max <= 0 {
+		max = defaultRetryMaxBackoff
+	}
+	if multiplier < 1 {
+		multiplier = defaultRetryMultiplier
+	}
+
+	delay := float64(initial) * math.Pow(multiplier, float64(attempt-1))
+	if delay > float64(max) {
+		delay = float64(max)
+	}
+
+	if p.Jitter > 0 {
+		jitter := math.Min(p.Jitter, 1) * delay
+		delay += jitter * (2*rand.Float64() - 1)
+	}
+
+	return time.Duration(delay)
+}
+
+// IsIdempotentMethod reports whether the conduit method only reads data, so
+// that calling it more than once is safe. This covers *.search, *.query,
+// *.lookup and *.info methods as well as legacy methods such as
+// differential.querydiffs or differential.getcommitmessage.
+func IsIdempotentMethod(method string) bool {
+	name := method[strings.LastIndex(method, ".")+1:]
+
+	switch name {
+	case "search", "query", "lookup", "info", "download":
+		return true
+	}
+
+	return strings.HasPrefix(name, "query") || strings.HasPrefix(name, "get")
+}
+
+// IsTransientError reports whether err is likely to go away if the call is
+// repeated: network timeouts, reset or refused connections, truncated
+// responses, rate limited calls, and 502, 503 and 504 responses from the web
+// tier.
+func IsTransientError(err error) bool {
+	if err == nil {
+		return false
+	}
+
+	// Deadlines of the caller's context are handled by shouldRetry, so a
+	// timeout here is one of a single attempt, e.g. ClientOptions.Timeout.
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+
+	if errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, syscall.ECONNREFUSED) ||
+		errors.Is(err, syscall.EPIPE) ||
+		errors.Is(err, io.EOF) ||
+		errors.Is(err, io.ErrUnexpectedEOF) {
+		return true
+	}
+
+	if isRateLimitError(err) {
+		return true
+	}
+
+	var conduitErr *ConduitError
+	if errors.As(err, &conduitErr) {
+		switch conduitErr.HTTPStatus() {
+		case http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout:
+			return true
+		}
+	}
+
+	return false
+}
+
+// getEndpointMethod extracts the conduit method name from an endpoint URI
+// built by GetEndpointURI.
+func getEndpointMethod(endpointURL string) string {
+	i := strings.LastIndex(endpointURL, "/api/")
+	if i < 0 {
+		return endpointURL
+	}
+
+	return endpointURL[i+len("/api/"):]
+}
+
+// sleepContext waits for the given duration or until the context is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
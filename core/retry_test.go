@@ -0,0 +1,196 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newFlakyServer returns a server which responds with 503 to the first
+// failures requests and with an empty result afterwards.
+func newFlakyServer(failures int32, calls *int32) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(
+		func(w http.ResponseWriter, req *http.Request) {
+			if atomic.AddInt32(calls, 1) <= failures {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte("Service Unavailable"))
+				return
+			}
+			w.Write([]byte(`{"result":{}}`))
+		}))
+}
+
+func TestPerformCall_withRetry(t *testing.T) {
+	var calls int32
+	ts := newFlakyServer(2, &calls)
+	defer ts.Close()
+
+	err := PerformCall(
+		ts.URL+"/api/maniphest.search",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			Retry: &RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+			},
+		},
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestPerformCall_withRetryExhausted(t *testing.T) {
+	var calls int32
+	ts := newFlakyServer(5, &calls)
+	defer ts.Close()
+
+	err := PerformCall(
+		ts.URL+"/api/maniphest.search",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			Retry: &RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+			},
+		},
+	)
+
+	assert.True(t, IsConduitError(err))
+	assert.Equal(t, int32(3), calls)
+}
+
+func TestPerformCall_withRetryOfMutatingMethod(t *testing.T) {
+	var calls int32
+	ts := newFlakyServer(2, &calls)
+	defer ts.Close()
+
+	err := PerformCall(
+		ts.URL+"/api/maniphest.edit",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			Retry: &RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+			},
+		},
+	)
+
+	assert.True(t, IsConduitError(err))
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestPerformCall_withRetryPredicate(t *testing.T) {
+	var calls int32
+	ts := newFlakyServer(2, &calls)
+	defer ts.Close()
+
+	err := PerformCall(
+		ts.URL+"/api/maniphest.search",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			Retry: &RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+				Retryable:      func(err error) bool { return false },
+			},
+		},
+	)
+
+	assert.True(t, IsConduitError(err))
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestPerformCallContext_withRetryCancelled(t *testing.T) {
+	var calls int32
+	ts := newFlakyServer(5, &calls)
+	defer ts.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := PerformCallContext(
+		ctx,
+		ts.URL+"/api/maniphest.search",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			Retry: &RetryPolicy{
+				MaxAttempts:    5,
+				InitialBackoff: time.Minute,
+			},
+		},
+	)
+
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Contains(t, err.Error(), "last error: ")
+	assert.Contains(t, err.Error(), "Service Unavailable")
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := &RetryPolicy{
+		InitialBackoff: 100 * time.Millisecond,
+		MaxBackoff:     time.Second,
+		Multiplier:     3,
+	}
+
+	assert.Equal(t, 100*time.Millisecond, policy.backoff(1))
+	assert.Equal(t, 300*time.Millisecond, policy.backoff(2))
+	assert.Equal(t, 900*time.Millisecond, policy.backoff(3))
+	assert.Equal(t, time.Second, policy.backoff(4))
+
+	policy.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		delay := policy.backoff(2)
+		assert.True(t, delay >= 150*time.Millisecond, delay)
+		assert.True(t, delay <= 450*time.Millisecond, delay)
+	}
+}
+
+func TestIsIdempotentMethod(t *testing.T) {
+	for method, want := range map[string]bool{
+		"maniphest.search":              true,
+		"differential.query":            true,
+		"differential.querydiffs":       true,
+		"differential.getcommitmessage": true,
+		"phid.lookup":                   true,
+		"conduit.getcapabilities":       true,
+		"maniphest.edit":                false,
+		"maniphest.createtask":          false,
+		"differential.createrawdiff":    false,
+		"conduit.connect":               false,
+	} {
+		assert.Equal(t, want, IsIdempotentMethod(method), method)
+	}
+}
+
+func TestIsTransientError(t *testing.T) {
+	assert.False(t, IsTransientError(nil))
+	assert.False(t, IsTransientError(context.Canceled))
+	assert.False(t, IsTransientError(errors.New("OMG")))
+	assert.False(t, IsTransientError(&ConduitError{code: "ERR-CONDUIT-CORE"}))
+	assert.False(t, IsTransientError(&ConduitError{code: "500", httpStatus: 500}))
+	assert.True(t, IsTransientError(&ConduitError{code: "503", httpStatus: 503}))
+	assert.True(t, IsTransientError(fmt.Errorf("read: %w", io.ErrUnexpectedEOF)))
+}
+
+func TestGetEndpointMethod(t *testing.T) {
+	assert.Equal(
+		t,
+		"maniphest.search",
+		getEndpointMethod(GetEndpointURI("https://phab.example.com/", "maniphest.search")),
+	)
+}
@@ -0,0 +1,27 @@
+//go:build go1.21
+// +build go1.21
+
+package core
+
+import (
+	"context"
+	"log/slog"
+)
+
+// NewSlogLogger returns a Logger which writes to logger.
+func NewSlogLogger(logger *slog.Logger) Logger {
+	return slogLogger{logger: logger}
+}
+
+type slogLogger struct {
+	logger *slog.Logger
+}
+
+func (l slogLogger) Log(
+	ctx context.Context,
+	level LogLevel,
+	msg string,
+	keyvals ...interface{},
+) {
+	l.logger.Log(ctx, slog.Level(level), msg, keyvals...)
+}
@@ -0,0 +1,31 @@
+//go:build go1.21
+// +build go1.21
+
+package core
+
+import (
+	"bytes"
+	"context"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.New(slog.NewTextHandler(
+		&buf,
+		&slog.HandlerOptions{Level: slog.LevelDebug},
+	)))
+
+	logger.Log(
+		context.Background(),
+		LogLevelDebug,
+		"conduit call",
+		"method", "phid.lookup",
+	)
+
+	assert.Contains(t, buf.String(), "level=DEBUG")
+	assert.Contains(t, buf.String(), `msg="conduit call" method=phid.lookup`)
+}
@@ -0,0 +1,50 @@
+package core
+
+import (
+	"context"
+	"fmt"
+)
+
+// TokenSource supplies OAuth access tokens. Token is called before every
+// attempt of a call to the Conduit API, including retries, so implementations
+// should cache the token and only refresh it when it is about to expire. It is
+// not called for conduit.getcapabilities, which needs no authentication.
+type TokenSource interface {
+	Token(ctx context.Context) (string, error)
+}
+
+// TokenSourceFunc is a function which implements TokenSource.
+type TokenSourceFunc func(ctx context.Context) (string, error)
+
+// Token calls f.
+func (f TokenSourceFunc) Token(ctx context.Context) (string, error) {
+	return f(ctx)
+}
+
+// unauthenticatedMethods lists the methods which are called without an access
+// token.
+var unauthenticatedMethods = map[string]bool{
+	"conduit.getcapabilities": true,
+}
+
+// withAccessToken returns options with AccessToken set to the token supplied
+// by options.TokenSource, if any and if the method needs authentication.
+func withAccessToken(
+	ctx context.Context,
+	method string,
+	options *ClientOptions,
+) (*ClientOptions, error) {
+	if options.TokenSource == nil || unauthenticatedMethods[method] {
+		return options, nil
+	}
+
+	token, err := options.TokenSource.Token(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("getting OAuth access token: %w", err)
+	}
+
+	withToken := *options
+	withToken.AccessToken = token
+
+	return &withToken, nil
+}
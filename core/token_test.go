@@ -0,0 +1,132 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/requests"
+	"github.com/uber/gonduit/test/server"
+)
+
+func TestPerformCall_withTokenSource(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+
+	var received []interface{}
+	ts.RegisterMethodFunc("phid.lookup", func(
+		params map[string]interface{},
+	) (int, map[string]interface{}) {
+		metadata, _ := params["__conduit__"].(map[string]interface{})
+		received = append(received, metadata["access_token"])
+
+		return 200, server.ResponseFromJSON(`{"result":{}}`)
+	})
+
+	tokens := []string{"token-1", "token-2"}
+	options := &ClientOptions{
+		AccessToken: "static-token",
+		TokenSource: TokenSourceFunc(func(ctx context.Context) (string, error) {
+			token := tokens[0]
+			tokens = tokens[1:]
+			return token, nil
+		}),
+	}
+
+	for i := 0; i < 2; i++ {
+		err := PerformCall(
+			ts.GetURL()+"/api/phid.lookup",
+			&requests.Request{},
+			&map[string]interface{}{},
+			options,
+		)
+		assert.Nil(t, err)
+	}
+
+	assert.Equal(t, []interface{}{"token-1", "token-2"}, received)
+	assert.Equal(t, "static-token", options.AccessToken)
+}
+
+func TestPerformCall_withFailingTokenSource(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+
+	failure := errors.New("refresh failed")
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		&requests.Request{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			TokenSource: TokenSourceFunc(func(ctx context.Context) (string, error) {
+				return "", failure
+			}),
+		},
+	)
+
+	assert.True(t, errors.Is(err, failure))
+}
+
+func TestPerformCall_withTokenSourceAndGetCapabilities(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+	ts.RegisterCapabilities()
+
+	calls := 0
+	err := PerformCall(
+		ts.GetURL()+"/api/conduit.getcapabilities",
+		nil,
+		&map[string]interface{}{},
+		&ClientOptions{
+			TokenSource: TokenSourceFunc(func(ctx context.Context) (string, error) {
+				calls++
+				return "", errors.New("no token for capabilities")
+			}),
+		},
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 0, calls)
+}
+
+func TestPerformCall_withTokenSourceAndRetries(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+
+	attempts := 0
+	ts.RegisterMethodFunc("phid.lookup", func(
+		params map[string]interface{},
+	) (int, map[string]interface{}) {
+		attempts++
+		if attempts == 1 {
+			return 200, server.ResponseFromJSON(`{
+				"error_code": "ERR-CONDUIT-CORE",
+				"error_info": "Try again."
+			}`)
+		}
+		return 200, server.ResponseFromJSON(`{"result":{}}`)
+	})
+
+	calls := 0
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		&requests.Request{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			Retry: &RetryPolicy{
+				MaxAttempts:    2,
+				InitialBackoff: time.Millisecond,
+				Retryable:      func(err error) bool { return true },
+			},
+			TokenSource: TokenSourceFunc(func(ctx context.Context) (string, error) {
+				calls++
+				return "token", nil
+			}),
+		},
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, attempts)
+	assert.Equal(t, 2, calls)
+}
@@ -0,0 +1,99 @@
+package core
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// Tracer creates spans for conduit calls. It can be adapted to
+// OpenTelemetry or other tracing libraries.
+type Tracer interface {
+	// Start starts a span named name, as a child of the span in ctx if any,
+	// and returns a context carrying the new span.
+	Start(ctx context.Context, name string) (context.Context, Span)
+	// Inject writes the trace context of ctx into the headers of an outgoing
+	// HTTP request.
+	Inject(ctx context.Context, header http.Header)
+}
+
+// Span is a traced conduit call.
+type Span interface {
+	SetAttribute(key string, value interface{})
+	RecordError(err error)
+	End()
+}
+
+// Attributes set on the spans of conduit calls. HTTP status, error code and
+// response size are the ones of the last attempt.
+const (
+	SpanAttributeMethod     = "conduit.method"
+	SpanAttributeHost       = "server.address"
+	SpanAttributeHTTPStatus = "http.response.status_code"
+	SpanAttributeErrorCode  = "conduit.error_code"
+	SpanAttributeSize       = "conduit.response.size"
+	SpanAttributeRetryCount = "conduit.retry_count"
+)
+
+// startSpan starts the span of a call to the Conduit API, if options.Tracer
+// is set.
+func startSpan(
+	ctx context.Context,
+	endpointURL string,
+	options *ClientOptions,
+) (context.Context, Span) {
+	if options.Tracer == nil {
+		return ctx, nil
+	}
+
+	method := getEndpointMethod(endpointURL)
+	ctx, span := options.Tracer.Start(ctx, method)
+	span.SetAttribute(SpanAttributeMethod, method)
+
+	if u, err := url.Parse(endpointURL); err == nil && u.Host != "" {
+		span.SetAttribute(SpanAttributeHost, u.Host)
+	}
+
+	return ctx, span
+}
+
+// endSpan records the outcome of a call on its span and ends it. a is the last
+// attempt of the call, if any was made.
+func endSpan(span Span, a *callAttempt, retries int, err error) {
+	if span == nil {
+		return
+	}
+
+	span.SetAttribute(SpanAttributeRetryCount, retries)
+
+	if a != nil {
+		if a.status != 0 {
+			span.SetAttribute(SpanAttributeHTTPStatus, a.status)
+		}
+
+		span.SetAttribute(SpanAttributeSize, len(a.body))
+	}
+
+	if err != nil {
+		var conduitErr *ConduitError
+		if errors.As(err, &conduitErr) {
+			span.SetAttribute(SpanAttributeErrorCode, conduitErr.Code())
+		}
+
+		span.RecordError(err)
+	}
+
+	span.End()
+}
+
+// injectTraceContext propagates the trace context of ctx on the request.
+func injectTraceContext(
+	ctx context.Context,
+	req *http.Request,
+	options *ClientOptions,
+) {
+	if options.Tracer != nil {
+		options.Tracer.Inject(ctx, req.Header)
+	}
+}
@@ -0,0 +1,123 @@
+package core
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/test/server"
+)
+
+type testSpan struct {
+	name       string
+	attributes map[string]interface{}
+	errors     []error
+	ended      bool
+}
+
+func (s *testSpan) SetAttribute(key string, value interface{}) {
+	s.attributes[key] = value
+}
+
+func (s *testSpan) RecordError(err error) {
+	s.errors = append(s.errors, err)
+}
+
+func (s *testSpan) End() {
+	s.ended = true
+}
+
+type testTracer struct {
+	spans []*testSpan
+}
+
+type testSpanKey struct{}
+
+func (t *testTracer) Start(
+	ctx context.Context,
+	name string,
+) (context.Context, Span) {
+	span := &testSpan{name: name, attributes: map[string]interface{}{}}
+	t.spans = append(t.spans, span)
+
+	return context.WithValue(ctx, testSpanKey{}, span), span
+}
+
+func (t *testTracer) Inject(ctx context.Context, header http.Header) {
+	if span, ok := ctx.Value(testSpanKey{}).(*testSpan); ok {
+		header.Set("Traceparent", span.name)
+	}
+}
+
+func TestPerformCall_withTracer(t *testing.T) {
+	var traceparent string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		traceparent = req.Header.Get("Traceparent")
+		w.Write([]byte(`{"result":{"T1":{}}}`))
+	}))
+	defer ts.Close()
+
+	tracer := &testTracer{}
+	err := PerformCall(
+		ts.URL+"/api/phid.lookup",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{Tracer: tracer},
+	)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "phid.lookup", traceparent)
+
+	if assert.Len(t, tracer.spans, 1) {
+		span := tracer.spans[0]
+		assert.True(t, span.ended)
+		assert.Empty(t, span.errors)
+		assert.Equal(t, map[string]interface{}{
+			SpanAttributeMethod:     "phid.lookup",
+			SpanAttributeHost:       ts.Listener.Addr().String(),
+			SpanAttributeHTTPStatus: 200,
+			SpanAttributeSize:       len(`{"result":{"T1":{}}}`),
+			SpanAttributeRetryCount: 0,
+		}, span.attributes)
+	}
+}
+
+func TestPerformCall_withTracerAndRetries(t *testing.T) {
+	ts := server.New()
+	defer ts.Close()
+	ts.RegisterMethod("phid.lookup", 503, server.ResponseFromJSON(`{
+		"error_code": "ERR-CONDUIT-CORE",
+		"error_info": "Service unavailable."
+	}`))
+
+	tracer := &testTracer{}
+	err := PerformCall(
+		ts.GetURL()+"/api/phid.lookup",
+		map[string]interface{}{},
+		&map[string]interface{}{},
+		&ClientOptions{
+			Tracer: tracer,
+			Retry: &RetryPolicy{
+				MaxAttempts:    3,
+				InitialBackoff: time.Millisecond,
+			},
+		},
+	)
+
+	assert.NotNil(t, err)
+	if assert.Len(t, tracer.spans, 1) {
+		span := tracer.spans[0]
+		assert.True(t, span.ended)
+		assert.Equal(t, []error{err}, span.errors)
+		assert.Equal(t, 2, span.attributes[SpanAttributeRetryCount])
+		assert.Equal(t, 503, span.attributes[SpanAttributeHTTPStatus])
+		assert.Equal(
+			t,
+			"ERR-CONDUIT-CORE",
+			span.attributes[SpanAttributeErrorCode],
+		)
+	}
+}
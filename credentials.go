@@ -0,0 +1,273 @@
+package gonduit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/uber/gonduit/core"
+)
+
+// Environment variables read by CredentialResolver.
+const (
+	// EnvHost is the URL of the Phabricator install.
+	EnvHost = "GONDUIT_HOST"
+	// EnvAPIToken is the conduit API token.
+	EnvAPIToken = "GONDUIT_API_TOKEN"
+	// EnvArcrc is the path of the .arcrc file, ~/.arcrc by default.
+	EnvArcrc = "GONDUIT_ARCRC"
+)
+
+// A CredentialResolver resolves the Phabricator host and credentials to
+// dial, in the same places as Arcanist.
+//
+// The host is the first of:
+//
+//   - Host
+//   - the GONDUIT_HOST environment variable
+//   - phabricator.uri in the nearest .arcconfig, from WorkingDir upwards
+//   - config.default in .arcrc
+//
+// The credentials are the first of:
+//
+//   - the GONDUIT_API_TOKEN environment variable
+//   - the token, or the certificate and user, of the host in .arcrc
+//
+// .arcrc is read from GONDUIT_ARCRC, ArcrcPath or ~/.arcrc.
+type CredentialResolver struct {
+	Host string
+
+	// ArcrcPath is the path of the .arcrc file.
+	ArcrcPath string
+	// WorkingDir is where the search for .arcconfig starts. The current
+	// working directory is used if empty.
+	WorkingDir string
+	// Getenv reads environment variables. os.Getenv is used if nil.
+	Getenv func(key string) string
+}
+
+// arcrc is the content of an .arcrc file.
+type arcrc struct {
+	Hosts map[string]arcrcHost `json:"hosts"`
+	// Config may be an empty JSON array when unset.
+	Config json.RawMessage `json:"config"`
+}
+
+type arcrcHost struct {
+	Token string `json:"token"`
+	User  string `json:"user"`
+	Cert  string `json:"cert"`
+}
+
+// arcconfig is the content of an .arcconfig file.
+type arcconfig struct {
+	PhabricatorURI string `json:"phabricator.uri"`
+	ConduitURI     string `json:"conduit_uri"`
+}
+
+// ResolveCredentials resolves the host and the options to dial it with, as
+// described by CredentialResolver. If host is empty, it is resolved too.
+//
+//	host, options, err := gonduit.ResolveCredentials("")
+//	if err != nil {
+//		return err
+//	}
+//
+//	client, err := gonduit.Dial(host, options)
+func ResolveCredentials(host string) (string, *core.ClientOptions, error) {
+	r := CredentialResolver{Host: host}
+
+	return r.Resolve()
+}
+
+// Resolve resolves the host and the options to dial it with. The host is
+// returned without the /api/ suffix used by .arcrc. When several .arcrc hosts
+// match it, the one spelled like the configured host wins.
+func (r *CredentialResolver) Resolve() (string, *core.ClientOptions, error) {
+	rc, err := r.readArcrc()
+	if err != nil {
+		return "", nil, err
+	}
+
+	host, err := r.resolveHost(rc)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rawHost := host
+	host = normalizeHost(host)
+
+	if token := r.getenv(EnvAPIToken); token != "" {
+		return host, &core.ClientOptions{APIToken: token}, nil
+	}
+
+	for _, key := range rc.hostKeys(rawHost, host) {
+		entry := rc.Hosts[key]
+
+		switch {
+		case entry.Token != "":
+			return host, &core.ClientOptions{APIToken: entry.Token}, nil
+		case entry.Cert != "":
+			return host, &core.ClientOptions{
+				Cert:     entry.Cert,
+				CertUser: entry.User,
+			}, nil
+		}
+	}
+
+	return "", nil, fmt.Errorf("%w: %s", core.ErrNoCredentials, host)
+}
+
+// hostKeys returns the keys of the .arcrc hosts matching host, in the order
+// they are tried: the host as configured, its canonical "/api/" form, then
+// every other key which normalizes to host, sorted.
+func (rc *arcrc) hostKeys(rawHost, host string) []string {
+	var keys []string
+	seen := map[string]bool{}
+
+	for _, key := range []string{rawHost, host + "/api/"} {
+		if _, ok := rc.Hosts[key]; ok && !seen[key] {
+			keys = append(keys, key)
+			seen[key] = true
+		}
+	}
+
+	var others []string
+	for key := range rc.Hosts {
+		if !seen[key] && normalizeHost(key) == host {
+			others = append(others, key)
+		}
+	}
+	sort.Strings(others)
+
+	return append(keys, others...)
+}
+
+func (r *CredentialResolver) resolveHost(rc *arcrc) (string, error) {
+	if r.Host != "" {
+		return r.Host, nil
+	}
+
+	if host := r.getenv(EnvHost); host != "" {
+		return host, nil
+	}
+
+	config, err := r.findArcconfig()
+	if err != nil {
+		return "", err
+	}
+
+	if config.PhabricatorURI != "" {
+		return config.PhabricatorURI, nil
+	}
+
+	if config.ConduitURI != "" {
+		return config.ConduitURI, nil
+	}
+
+	var rcConfig struct {
+		Default string `json:"default"`
+	}
+
+	// An unset config is serialized by Arcanist as an empty array.
+	if json.Unmarshal(rc.Config, &rcConfig) == nil && rcConfig.Default != "" {
+		return rcConfig.Default, nil
+	}
+
+	return "", core.ErrNoHost
+}
+
+// readArcrc reads the .arcrc file. A missing file is treated as empty.
+func (r *CredentialResolver) readArcrc() (*arcrc, error) {
+	path := r.getenv(EnvArcrc)
+	if path == "" {
+		path = r.ArcrcPath
+	}
+
+	if path == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return &arcrc{}, nil
+		}
+
+		path = filepath.Join(home, ".arcrc")
+	}
+
+	var rc arcrc
+	if err := readJSONFile(path, &rc); err != nil {
+		return nil, err
+	}
+
+	return &rc, nil
+}
+
+// findArcconfig reads the nearest .arcconfig file, from the working directory
+// upwards.
+func (r *CredentialResolver) findArcconfig() (*arcconfig, error) {
+	dir := r.WorkingDir
+	if dir == "" {
+		wd, err := os.Getwd()
+		if err != nil {
+			return &arcconfig{}, nil
+		}
+
+		dir = wd
+	}
+
+	for {
+		path := filepath.Join(dir, ".arcconfig")
+		if _, err := os.Stat(path); err == nil {
+			var config arcconfig
+			if err := readJSONFile(path, &config); err != nil {
+				return nil, err
+			}
+
+			return &config, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return &arcconfig{}, nil
+		}
+
+		dir = parent
+	}
+}
+
+func (r *CredentialResolver) getenv(key string) string {
+	if r.Getenv != nil {
+		return r.Getenv(key)
+	}
+
+	return os.Getenv(key)
+}
+
+// readJSONFile decodes the JSON file at path into v. A missing file leaves v
+// untouched.
+func readJSONFile(path string, v interface{}) error {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// normalizeHost strips the API path of .arcrc host keys, so that they can be
+// compared with the host passed to Dial.
+func normalizeHost(host string) string {
+	host = strings.TrimSuffix(host, "/")
+	host = strings.TrimSuffix(host, "/api")
+
+	return strings.TrimSuffix(host, "/")
+}
@@ -0,0 +1,214 @@
+package gonduit
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/core"
+)
+
+const testArcrc = `{
+	"hosts": {
+		"https://phab.example.com/api/": {"token": "api-arcrc"},
+		"https://legacy.example.com/api/": {
+			"user": "alice",
+			"cert": "some-certificate"
+		}
+	},
+	"config": {"default": "https://phab.example.com/"}
+}`
+
+// credentialsDir creates a directory with the given .arcrc and a project
+// with the given .arcconfig, and returns the paths of both.
+func credentialsDir(t *testing.T, rc, config string) (string, string) {
+	dir, err := ioutil.TempDir("", "gonduit")
+	assert.Nil(t, err)
+
+	project := filepath.Join(dir, "project", "src")
+	assert.Nil(t, os.MkdirAll(project, 0755))
+
+	arcrcPath := filepath.Join(dir, ".arcrc")
+	if rc != "" {
+		assert.Nil(t, ioutil.WriteFile(arcrcPath, []byte(rc), 0600))
+	}
+
+	if config != "" {
+		assert.Nil(t, ioutil.WriteFile(
+			filepath.Join(dir, "project", ".arcconfig"),
+			[]byte(config),
+			0644,
+		))
+	}
+
+	return arcrcPath, project
+}
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string {
+		return vars[key]
+	}
+}
+
+func TestCredentialResolver_withArcrc(t *testing.T) {
+	arcrcPath, project := credentialsDir(t, testArcrc, "")
+	defer os.RemoveAll(filepath.Dir(arcrcPath))
+
+	r := CredentialResolver{
+		ArcrcPath:  arcrcPath,
+		WorkingDir: project,
+		Getenv:     env(nil),
+	}
+	host, options, err := r.Resolve()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://phab.example.com", host)
+	assert.Equal(t, &core.ClientOptions{APIToken: "api-arcrc"}, options)
+}
+
+func TestCredentialResolver_withArcconfig(t *testing.T) {
+	arcrcPath, project := credentialsDir(
+		t,
+		testArcrc,
+		`{"phabricator.uri": "https://legacy.example.com/"}`,
+	)
+	defer os.RemoveAll(filepath.Dir(arcrcPath))
+
+	r := CredentialResolver{
+		ArcrcPath:  arcrcPath,
+		WorkingDir: project,
+		Getenv:     env(nil),
+	}
+	host, options, err := r.Resolve()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://legacy.example.com", host)
+	assert.Equal(t, &core.ClientOptions{
+		Cert:     "some-certificate",
+		CertUser: "alice",
+	}, options)
+}
+
+func TestCredentialResolver_withEnvironment(t *testing.T) {
+	arcrcPath, project := credentialsDir(
+		t,
+		testArcrc,
+		`{"phabricator.uri": "https://legacy.example.com/"}`,
+	)
+	defer os.RemoveAll(filepath.Dir(arcrcPath))
+
+	r := CredentialResolver{
+		WorkingDir: project,
+		Getenv: env(map[string]string{
+			EnvHost:     "https://phab.example.com/api/",
+			EnvAPIToken: "api-env",
+			EnvArcrc:    arcrcPath,
+		}),
+	}
+	host, options, err := r.Resolve()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://phab.example.com", host)
+	assert.Equal(t, &core.ClientOptions{APIToken: "api-env"}, options)
+}
+
+func TestCredentialResolver_withExplicitHost(t *testing.T) {
+	arcrcPath, project := credentialsDir(t, testArcrc, "")
+	defer os.RemoveAll(filepath.Dir(arcrcPath))
+
+	r := CredentialResolver{
+		Host:       "https://legacy.example.com",
+		ArcrcPath:  arcrcPath,
+		WorkingDir: project,
+		Getenv:     env(map[string]string{EnvHost: "https://other.example.com"}),
+	}
+	host, options, err := r.Resolve()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "https://legacy.example.com", host)
+	assert.Equal(t, "some-certificate", options.Cert)
+}
+
+func TestCredentialResolver_withNoHost(t *testing.T) {
+	arcrcPath, project := credentialsDir(t, `{"hosts": {}, "config": []}`, "")
+	defer os.RemoveAll(filepath.Dir(arcrcPath))
+
+	r := CredentialResolver{
+		ArcrcPath:  arcrcPath,
+		WorkingDir: project,
+		Getenv:     env(nil),
+	}
+	_, _, err := r.Resolve()
+
+	assert.Equal(t, core.ErrNoHost, err)
+}
+
+func TestCredentialResolver_withNoCredentials(t *testing.T) {
+	arcrcPath, project := credentialsDir(t, "", "")
+	defer os.RemoveAll(filepath.Dir(arcrcPath))
+
+	r := CredentialResolver{
+		Host:       "https://phab.example.com",
+		ArcrcPath:  arcrcPath,
+		WorkingDir: project,
+		Getenv:     env(nil),
+	}
+	_, _, err := r.Resolve()
+
+	assert.True(t, errors.Is(err, core.ErrNoCredentials))
+}
+
+func TestCredentialResolver_withInvalidArcrc(t *testing.T) {
+	arcrcPath, project := credentialsDir(t, "{", "")
+	defer os.RemoveAll(filepath.Dir(arcrcPath))
+
+	r := CredentialResolver{
+		ArcrcPath:  arcrcPath,
+		WorkingDir: project,
+		Getenv:     env(nil),
+	}
+	_, _, err := r.Resolve()
+
+	assert.NotNil(t, err)
+}
+
+func TestCredentialResolver_withDuplicateHosts(t *testing.T) {
+	arcrcPath, project := credentialsDir(t, `{
+		"hosts": {
+			"https://phab.example.com/api/": {"token": "api-canonical"},
+			"https://phab.example.com": {"token": "api-bare"},
+			"https://phab.example.com/": {"token": "api-slash"},
+			"https://phab.example.com/api": {"token": "api-noslash"}
+		}
+	}`, "")
+	defer os.RemoveAll(filepath.Dir(arcrcPath))
+
+	tests := map[string]string{
+		"https://phab.example.com":      "api-bare",
+		"https://phab.example.com/":     "api-slash",
+		"https://phab.example.com/api/": "api-canonical",
+		"https://phab.example.com/api":  "api-noslash",
+		"https://phab.example.com//":    "api-canonical",
+	}
+
+	for configured, token := range tests {
+		r := CredentialResolver{
+			Host:       configured,
+			ArcrcPath:  arcrcPath,
+			WorkingDir: project,
+			Getenv:     env(nil),
+		}
+
+		// Map iteration order is random, so resolve repeatedly.
+		for i := 0; i < 20; i++ {
+			host, options, err := r.Resolve()
+
+			assert.Nil(t, err)
+			assert.Equal(t, "https://phab.example.com", host)
+			assert.Equal(t, token, options.APIToken, configured)
+		}
+	}
+}
@@ -43,6 +43,10 @@ func (d *Dialer) Dial(
 
 // DialContext connects to conduit and confirms the API capabilities for future calls,
 // passing the given context through.
+//
+// The connection uses the best authentication scheme and encodings supported
+// by both the client and the server. An error is returned if the server does
+// not support any of them.
 func (d *Dialer) DialContext(
 	ctx context.Context,
 	host string,
@@ -50,6 +54,14 @@ func (d *Dialer) DialContext(
 ) (*Conn, error) {
 	var res responses.ConduitCapabilitiesResponse
 
+	// Every call made through the connection shares one HTTP client, so that
+	// keep-alive connections are reused.
+	if options.Client == nil {
+		withClient := *options
+		withClient.Client = core.NewHTTPClient(options)
+		options = &withClient
+	}
+
 	// We use conduit.connect for authentication and it establishes a session.
 	err := core.PerformCallContext(
 		ctx,
@@ -63,41 +75,114 @@ func (d *Dialer) DialContext(
 	}
 
 	// Now, we need to assert that the conduit API supports this client.
-	assertSupportedCapabilities(res, options)
+	negotiated, err := negotiateCapabilities(res, options)
+	if err != nil {
+		return nil, err
+	}
 
 	conn := Conn{
 		host:         host,
 		capabilities: &res,
+		negotiated:   negotiated,
 		dialer:       d,
-		options:      options,
+		options:      negotiated.apply(options),
 	}
 
 	return &conn, nil
 }
 
-func assertSupportedCapabilities(
+// negotiatedCapabilities holds the settings picked for a connection out of the
+// capabilities advertised by the server.
+type negotiatedCapabilities struct {
+	authScheme core.AuthScheme
+	input      core.Encoding
+	output     core.Encoding
+}
+
+// apply returns a copy of options which only contains the credentials of the
+// negotiated authentication scheme.
+func (n *negotiatedCapabilities) apply(
+	options *core.ClientOptions,
+) *core.ClientOptions {
+	applied := *options
+
+	if n.authScheme != core.AuthSchemeToken {
+		applied.APIToken = ""
+	}
+
+	if n.authScheme != core.AuthSchemeOAuth {
+		applied.AccessToken = ""
+		applied.TokenSource = nil
+	}
+
+	return &applied
+}
+
+// clientInputEncodings lists the input encodings supported by the client,
+// in order of preference.
+var clientInputEncodings = []core.Encoding{
+	core.EncodingURLEncoded,
+}
+
+// clientOutputEncodings lists the output encodings supported by the client,
+// in order of preference.
+var clientOutputEncodings = []core.Encoding{
+	core.EncodingJSON,
+}
+
+// negotiateCapabilities picks the authentication scheme and encodings to use
+// with a server advertising the given capabilities.
+func negotiateCapabilities(
 	res responses.ConduitCapabilitiesResponse,
 	options *core.ClientOptions,
-) error {
-	if options.APIToken != "" {
-		if !util.ContainsString(res.Authentication, "token") {
-			return core.ErrTokenAuthUnsupported
-		}
+) (*negotiatedCapabilities, error) {
+	var negotiated negotiatedCapabilities
+
+	// Token authentication is preferred because it does not need a session.
+	switch {
+	case options.APIToken != "" &&
+		util.ContainsString(res.Authentication, string(core.AuthSchemeToken)):
+		negotiated.authScheme = core.AuthSchemeToken
+	case hasAccessToken(options) &&
+		util.ContainsString(res.Authentication, string(core.AuthSchemeOAuth)):
+		negotiated.authScheme = core.AuthSchemeOAuth
+	case options.Cert != "" &&
+		util.ContainsString(res.Authentication, string(core.AuthSchemeSession)):
+		negotiated.authScheme = core.AuthSchemeSession
+	case options.APIToken != "":
+		return nil, core.ErrTokenAuthUnsupported
+	case hasAccessToken(options):
+		return nil, core.ErrOAuthUnsupported
+	case options.Cert != "":
+		return nil, core.ErrSessionAuthUnsupported
 	}
 
-	if options.Cert != "" {
-		if !util.ContainsString(res.Authentication, "session") {
-			return core.ErrSessionAuthUnsupported
-		}
+	negotiated.input = pickEncoding(clientInputEncodings, res.Input)
+	if negotiated.input == "" {
+		return nil, core.ErrURLEncodedInputUnsupported
 	}
 
-	if !util.ContainsString(res.Input, "urlencoded") {
-		return core.ErrURLEncodedInputUnsupported
+	negotiated.output = pickEncoding(clientOutputEncodings, res.Output)
+	if negotiated.output == "" {
+		return nil, core.ErrJSONOutputUnsupported
 	}
 
-	if !util.ContainsString(res.Output, "json") {
-		return core.ErrJSONOutputUnsupported
+	return &negotiated, nil
+}
+
+// hasAccessToken reports whether options configure OAuth authentication.
+func hasAccessToken(options *core.ClientOptions) bool {
+	return options.AccessToken != "" || options.TokenSource != nil
+}
+
+// pickEncoding returns the first of the client encodings the server supports,
+// or an empty encoding if there is none.
+func pickEncoding(client []core.Encoding, server []string) core.Encoding {
+	for _, encoding := range client {
+		if util.ContainsString(server, string(encoding)) {
+			return encoding
+		}
 	}
 
-	return nil
+	return ""
 }
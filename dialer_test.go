@@ -1,10 +1,13 @@
 package gonduit
 
 import (
+	"context"
+	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/uber/gonduit/core"
+	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 	"github.com/uber/gonduit/test/server"
 )
@@ -15,11 +18,15 @@ func TestDial(t *testing.T) {
 
 	s.RegisterCapabilities()
 
-	_, err := Dial(s.GetURL(), &core.ClientOptions{
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
 		APIToken: "some-token",
 	})
 
 	assert.Nil(t, err)
+	assert.Equal(t, []string{"token", "session"}, c.Capabilities().Authentication)
+	assert.Equal(t, core.AuthSchemeToken, c.AuthScheme())
+	assert.Equal(t, core.EncodingURLEncoded, c.InputEncoding())
+	assert.Equal(t, core.EncodingJSON, c.OutputEncoding())
 }
 
 func TestDial_withInvalid(t *testing.T) {
@@ -37,70 +44,265 @@ func TestDial_withInvalid(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
-func TestAssertSupportedCapabilities(t *testing.T) {
+func TestDial_withUnsupportedCapabilities(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+
+	s.RegisterMethod("conduit.getcapabilities", 200, server.ResponseFromJSON(`{
+		"result": {
+			"authentication": ["session"],
+			"input": ["urlencoded"],
+			"output": ["json"]
+		}
+	}`))
+
+	_, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+
+	assert.Equal(t, core.ErrTokenAuthUnsupported, err)
+}
+
+func TestDial_withSessionFallback(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+
+	s.RegisterMethod("conduit.getcapabilities", 200, server.ResponseFromJSON(`{
+		"result": {
+			"authentication": ["session"],
+			"input": ["urlencoded"],
+			"output": ["json"]
+		}
+	}`))
+
+	options := &core.ClientOptions{
+		APIToken: "some-token",
+		Cert:     "some-certificate",
+		CertUser: "alice",
+	}
+	c, err := Dial(s.GetURL(), options)
+
+	assert.Nil(t, err)
+	assert.Equal(t, core.AuthSchemeSession, c.AuthScheme())
+	assert.Equal(t, "", c.options.APIToken)
+	assert.Equal(t, "some-certificate", c.options.Cert)
+	// The caller's options are left untouched.
+	assert.Equal(t, "some-token", options.APIToken)
+}
+
+func TestNegotiateCapabilities(t *testing.T) {
 	response := responses.ConduitCapabilitiesResponse{
 		Input:  []string{"urlencoded"},
 		Output: []string{"json"},
 	}
 
-	assert.Nil(t, assertSupportedCapabilities(response, &core.ClientOptions{}))
+	negotiated, err := negotiateCapabilities(response, &core.ClientOptions{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, &negotiatedCapabilities{
+		authScheme: core.AuthSchemeNone,
+		input:      core.EncodingURLEncoded,
+		output:     core.EncodingJSON,
+	}, negotiated)
 }
 
-func TestAssertSupportedCapabilities_withMissingInput(t *testing.T) {
+func TestNegotiateCapabilities_withMissingInput(t *testing.T) {
 	response := responses.ConduitCapabilitiesResponse{
 		Input:  []string{"fake"},
 		Output: []string{"json"},
 	}
 
-	assert.Equal(
-		t,
-		core.ErrURLEncodedInputUnsupported,
-		assertSupportedCapabilities(response, &core.ClientOptions{}),
-	)
+	_, err := negotiateCapabilities(response, &core.ClientOptions{})
+
+	assert.Equal(t, core.ErrURLEncodedInputUnsupported, err)
 }
 
-func TestAssertSupportedCapabilities_withMissingOutput(t *testing.T) {
+func TestNegotiateCapabilities_withMissingOutput(t *testing.T) {
 	response := responses.ConduitCapabilitiesResponse{
 		Input:  []string{"urlencoded"},
 		Output: []string{"fake"},
 	}
 
-	assert.Equal(
-		t,
-		core.ErrJSONOutputUnsupported,
-		assertSupportedCapabilities(response, &core.ClientOptions{}),
-	)
+	_, err := negotiateCapabilities(response, &core.ClientOptions{})
+
+	assert.Equal(t, core.ErrJSONOutputUnsupported, err)
 }
 
-func TestAssertSupportedCapabilities_withNoToken(t *testing.T) {
+func TestNegotiateCapabilities_withNoToken(t *testing.T) {
 	response := responses.ConduitCapabilitiesResponse{
 		Authentication: []string{"session"},
 		Input:          []string{"urlencoded"},
 		Output:         []string{"json"},
 	}
 
-	assert.Equal(
-		t,
-		core.ErrTokenAuthUnsupported,
-		assertSupportedCapabilities(response, &core.ClientOptions{
-			APIToken: "super-secret-token",
-		}),
-	)
+	_, err := negotiateCapabilities(response, &core.ClientOptions{
+		APIToken: "super-secret-token",
+	})
+
+	assert.Equal(t, core.ErrTokenAuthUnsupported, err)
 }
 
-func TestAssertSupportedCapabilities_withNoCertificate(t *testing.T) {
+func TestNegotiateCapabilities_withNoCertificate(t *testing.T) {
 	response := responses.ConduitCapabilitiesResponse{
 		Authentication: []string{"token"},
 		Input:          []string{"urlencoded"},
 		Output:         []string{"json"},
 	}
 
+	_, err := negotiateCapabilities(response, &core.ClientOptions{
+		Cert:     "super-secret-token",
+		CertUser: "alice",
+	})
+
+	assert.Equal(t, core.ErrSessionAuthUnsupported, err)
+}
+
+func TestNegotiateCapabilities_withTokenAndCertificate(t *testing.T) {
+	response := responses.ConduitCapabilitiesResponse{
+		Authentication: []string{"token", "session"},
+		Input:          []string{"urlencoded"},
+		Output:         []string{"json"},
+	}
+
+	negotiated, err := negotiateCapabilities(response, &core.ClientOptions{
+		APIToken: "super-secret-token",
+		Cert:     "super-secret-certificate",
+		CertUser: "alice",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, core.AuthSchemeToken, negotiated.authScheme)
+}
+
+func TestDial_withSharedHTTPClient(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+
+	s.RegisterCapabilities()
+
+	options := &core.ClientOptions{
+		APIToken: "some-token",
+	}
+	c, err := Dial(s.GetURL(), options)
+
+	assert.Nil(t, err)
+	assert.NotNil(t, c.options.Client)
+	assert.Nil(t, options.Client)
+}
+
+type spanNames []string
+
+func (s *spanNames) Start(
+	ctx context.Context,
+	name string,
+) (context.Context, core.Span) {
+	*s = append(*s, name)
+	return ctx, noopSpan{}
+}
+
+func (s *spanNames) Inject(ctx context.Context, header http.Header) {}
+
+type noopSpan struct{}
+
+func (noopSpan) SetAttribute(key string, value interface{}) {}
+func (noopSpan) RecordError(err error)                      {}
+func (noopSpan) End()                                       {}
+
+func TestDial_withTracer(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+
+	s.RegisterMethod("conduit.getcapabilities", 200, server.ResponseFromJSON(`{
+		"result": {
+			"authentication": ["session"],
+			"input": ["urlencoded"],
+			"output": ["json"]
+		}
+	}`))
+	s.RegisterMethod("conduit.connect", 200, server.ResponseFromJSON(`{
+		"result": {"connectionID": 1, "sessionKey": "some-session"}
+	}`))
+
+	tracer := &spanNames{}
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		Cert:     "some-certificate",
+		CertUser: "alice",
+		Tracer:   tracer,
+	})
+	assert.Nil(t, err)
+
+	assert.Nil(t, c.Connect())
 	assert.Equal(
 		t,
-		core.ErrSessionAuthUnsupported,
-		assertSupportedCapabilities(response, &core.ClientOptions{
-			Cert:     "super-secret-token",
-			CertUser: "alice",
-		}),
+		&spanNames{"conduit.getcapabilities", "conduit.connect"},
+		tracer,
 	)
 }
+
+func TestNegotiateCapabilities_withAccessToken(t *testing.T) {
+	response := responses.ConduitCapabilitiesResponse{
+		Authentication: []string{"token", "oauth", "session"},
+		Input:          []string{"urlencoded"},
+		Output:         []string{"json"},
+	}
+
+	negotiated, err := negotiateCapabilities(response, &core.ClientOptions{
+		AccessToken: "some-access-token",
+		Cert:        "some-certificate",
+	})
+
+	assert.Nil(t, err)
+	assert.Equal(t, core.AuthSchemeOAuth, negotiated.authScheme)
+}
+
+func TestNegotiateCapabilities_withNoOAuth(t *testing.T) {
+	response := responses.ConduitCapabilitiesResponse{
+		Authentication: []string{"token", "session"},
+		Input:          []string{"urlencoded"},
+		Output:         []string{"json"},
+	}
+
+	_, err := negotiateCapabilities(response, &core.ClientOptions{
+		TokenSource: core.TokenSourceFunc(
+			func(ctx context.Context) (string, error) {
+				return "some-access-token", nil
+			},
+		),
+	})
+
+	assert.Equal(t, core.ErrOAuthUnsupported, err)
+}
+
+func TestDial_withAccessToken(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+
+	s.RegisterMethod("conduit.getcapabilities", 200, server.ResponseFromJSON(`{
+		"result": {
+			"authentication": ["token", "oauth"],
+			"input": ["urlencoded"],
+			"output": ["json"]
+		}
+	}`))
+
+	var accessToken interface{}
+	s.RegisterMethodFunc("phid.lookup", func(
+		params map[string]interface{},
+	) (int, map[string]interface{}) {
+		metadata, _ := params["__conduit__"].(map[string]interface{})
+		accessToken = metadata["access_token"]
+
+		return 200, server.ResponseFromJSON(`{"result":{}}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		AccessToken: "some-access-token",
+		Cert:        "some-certificate",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, core.AuthSchemeOAuth, c.AuthScheme())
+
+	_, err = c.PHIDLookup(requests.PHIDLookupRequest{Names: []string{"T1"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "some-access-token", accessToken)
+}
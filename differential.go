@@ -1,6 +1,9 @@
 package gonduit
 
 import (
+	"context"
+
+	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -11,10 +14,21 @@ const DifferentialQueryMethod = "differential.query"
 // DifferentialQuery performs a call to differential.query.
 func (c *Conn) DifferentialQuery(
 	req requests.DifferentialQueryRequest,
+) (*responses.DifferentialQueryResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialQueryContext(ctx, req)
+}
+
+// DifferentialQueryContext performs a call to differential.query, passing
+// through the given context.
+func (c *Conn) DifferentialQueryContext(
+	ctx context.Context,
+	req requests.DifferentialQueryRequest,
 ) (*responses.DifferentialQueryResponse, error) {
 	var res responses.DifferentialQueryResponse
 
-	if err := c.Call(DifferentialQueryMethod, &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, DifferentialQueryMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -24,10 +38,21 @@ func (c *Conn) DifferentialQuery(
 // DifferentialQueryDiffs performs a call to differential.querydiffs.
 func (c *Conn) DifferentialQueryDiffs(
 	req requests.DifferentialQueryDiffsRequest,
+) (*responses.DifferentialQueryDiffsResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialQueryDiffsContext(ctx, req)
+}
+
+// DifferentialQueryDiffsContext performs a call to differential.querydiffs,
+// passing through the given context.
+func (c *Conn) DifferentialQueryDiffsContext(
+	ctx context.Context,
+	req requests.DifferentialQueryDiffsRequest,
 ) (*responses.DifferentialQueryDiffsResponse, error) {
 	var res responses.DifferentialQueryDiffsResponse
 
-	if err := c.Call("differential.querydiffs", &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, "differential.querydiffs", &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -40,10 +65,21 @@ const DifferentialGetCommitPathsMethod = "differential.getcommitpaths"
 // DifferentialGetCommitPaths performs a call to differential.getcommitpaths.
 func (c *Conn) DifferentialGetCommitPaths(
 	req requests.DifferentialGetCommitPathsRequest,
+) (*responses.DifferentialGetCommitPathsResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialGetCommitPathsContext(ctx, req)
+}
+
+// DifferentialGetCommitPathsContext performs a call to
+// differential.getcommitpaths, passing through the given context.
+func (c *Conn) DifferentialGetCommitPathsContext(
+	ctx context.Context,
+	req requests.DifferentialGetCommitPathsRequest,
 ) (*responses.DifferentialGetCommitPathsResponse, error) {
 	var res responses.DifferentialGetCommitPathsResponse
 
-	if err := c.Call(DifferentialGetCommitPathsMethod, &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, DifferentialGetCommitPathsMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -56,11 +92,102 @@ const DifferentialGetCommitMessageMethod = "differential.getcommitmessage"
 // DifferentialGetCommitMessage performs a call to differential.getcommitmessage.
 func (c *Conn) DifferentialGetCommitMessage(
 	req requests.DifferentialGetCommitMessageRequest,
+) (*responses.DifferentialGetCommitMessageResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialGetCommitMessageContext(ctx, req)
+}
+
+// DifferentialGetCommitMessageContext performs a call to
+// differential.getcommitmessage, passing through the given context.
+func (c *Conn) DifferentialGetCommitMessageContext(
+	ctx context.Context,
+	req requests.DifferentialGetCommitMessageRequest,
 ) (*responses.DifferentialGetCommitMessageResponse, error) {
 	var res responses.DifferentialGetCommitMessageResponse
 
-	if err := c.Call(
-		DifferentialGetCommitMessageMethod, &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, DifferentialGetCommitMessageMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DifferentialCreateRawDiffMethod is method name on Phabricator API.
+const DifferentialCreateRawDiffMethod = "differential.createrawdiff"
+
+// DifferentialCreateRawDiff performs a call to differential.createrawdiff.
+func (c *Conn) DifferentialCreateRawDiff(
+	req requests.DifferentialCreateRawDiffRequest,
+) (*responses.DifferentialCreateRawDiffResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialCreateRawDiffContext(ctx, req)
+}
+
+// DifferentialCreateRawDiffContext performs a call to differential.createrawdiff,
+// passing through the given context.
+func (c *Conn) DifferentialCreateRawDiffContext(
+	ctx context.Context,
+	req requests.DifferentialCreateRawDiffRequest,
+) (*responses.DifferentialCreateRawDiffResponse, error) {
+	var res responses.DifferentialCreateRawDiffResponse
+
+	if err := c.CallContext(
+		ctx, DifferentialCreateRawDiffMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DifferentialCreateDiffMethod is method name on Phabricator API.
+const DifferentialCreateDiffMethod = "differential.creatediff"
+
+// DifferentialCreateDiff performs a call to differential.creatediff.
+func (c *Conn) DifferentialCreateDiff(
+	req requests.DifferentialCreateDiffRequest,
+) (*responses.DifferentialCreateDiffResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialCreateDiffContext(ctx, req)
+}
+
+// DifferentialCreateDiffContext performs a call to differential.creatediff,
+// passing through the given context.
+func (c *Conn) DifferentialCreateDiffContext(
+	ctx context.Context,
+	req requests.DifferentialCreateDiffRequest,
+) (*responses.DifferentialCreateDiffResponse, error) {
+	var res responses.DifferentialCreateDiffResponse
+
+	if err := c.CallContext(
+		ctx, DifferentialCreateDiffMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DifferentialRevisionEditMethod is method name on Phabricator API.
+const DifferentialRevisionEditMethod = "differential.revision.edit"
+
+// DifferentialRevisionEdit performs a call to differential.revision.edit.
+func (c *Conn) DifferentialRevisionEdit(
+	req requests.DifferentialRevisionEditRequest,
+) (*responses.EditResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialRevisionEditContext(ctx, req)
+}
+
+// DifferentialRevisionEditContext performs a call to
+// differential.revision.edit, passing through the given context.
+func (c *Conn) DifferentialRevisionEditContext(
+	ctx context.Context,
+	req requests.DifferentialRevisionEditRequest,
+) (*responses.EditResponse, error) {
+	var res responses.EditResponse
+
+	if err := c.CallContext(
+		ctx, DifferentialRevisionEditMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -73,10 +200,21 @@ const DifferentialRevisionSearchMethod = "differential.revision.search"
 // DifferentialRevisionSearch performs a call to differential.revision.search.
 func (c *Conn) DifferentialRevisionSearch(
 	req requests.DifferentialRevisionSearchRequest,
+) (*responses.DifferentialRevisionSearchResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialRevisionSearchContext(ctx, req)
+}
+
+// DifferentialRevisionSearchContext performs a call to
+// differential.revision.search, passing through the given context.
+func (c *Conn) DifferentialRevisionSearchContext(
+	ctx context.Context,
+	req requests.DifferentialRevisionSearchRequest,
 ) (*responses.DifferentialRevisionSearchResponse, error) {
 	var res responses.DifferentialRevisionSearchResponse
 
-	if err := c.Call(DifferentialRevisionSearchMethod, &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, DifferentialRevisionSearchMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -89,12 +227,455 @@ const DifferentialDiffSearchMethod = "differential.diff.search"
 // DifferentialDiffSearch performs a call to differential.diff.search.
 func (c *Conn) DifferentialDiffSearch(
 	req requests.DifferentialDiffSearchRequest,
+) (*responses.DifferentialDiffSearchResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialDiffSearchContext(ctx, req)
+}
+
+// DifferentialDiffSearchContext performs a call to differential.diff.search,
+// passing through the given context.
+func (c *Conn) DifferentialDiffSearchContext(
+	ctx context.Context,
+	req requests.DifferentialDiffSearchRequest,
 ) (*responses.DifferentialDiffSearchResponse, error) {
 	var res responses.DifferentialDiffSearchResponse
 
-	if err := c.Call(DifferentialDiffSearchMethod, &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, DifferentialDiffSearchMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DifferentialGetRawDiffMethod is method name on Phabricator API.
+const DifferentialGetRawDiffMethod = "differential.getrawdiff"
+
+// DifferentialGetRawDiff performs a call to differential.getrawdiff. The
+// unified diff can be parsed with unidiff.ParseFiles.
+func (c *Conn) DifferentialGetRawDiff(
+	req requests.DifferentialGetRawDiffRequest,
+) (*responses.DifferentialGetRawDiffResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialGetRawDiffContext(ctx, req)
+}
+
+// DifferentialGetRawDiffContext performs a call to differential.getrawdiff,
+// passing through the given context.
+func (c *Conn) DifferentialGetRawDiffContext(
+	ctx context.Context,
+	req requests.DifferentialGetRawDiffRequest,
+) (*responses.DifferentialGetRawDiffResponse, error) {
+	var res responses.DifferentialGetRawDiffResponse
+
+	if err := c.CallContext(
+		ctx, DifferentialGetRawDiffMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DifferentialChangesetSearchMethod is method name on Phabricator API.
+const DifferentialChangesetSearchMethod = "differential.changeset.search"
+
+// DifferentialChangesetSearch performs a call to differential.changeset.search.
+func (c *Conn) DifferentialChangesetSearch(
+	req requests.DifferentialChangesetSearchRequest,
+) (*responses.DifferentialChangesetSearchResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialChangesetSearchContext(ctx, req)
+}
+
+// DifferentialChangesetSearchContext performs a call to
+// differential.changeset.search, passing through the given context.
+func (c *Conn) DifferentialChangesetSearchContext(
+	ctx context.Context,
+	req requests.DifferentialChangesetSearchRequest,
+) (*responses.DifferentialChangesetSearchResponse, error) {
+	var res responses.DifferentialChangesetSearchResponse
+
+	if err := c.CallContext(
+		ctx, DifferentialChangesetSearchMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DifferentialCreateInlineMethod is method name on Phabricator API.
+const DifferentialCreateInlineMethod = "differential.createinline"
+
+// DifferentialCreateInline performs a call to differential.createinline. The
+// inline comment stays a draft until it is published, either with
+// DifferentialCreateComment and AttachInlines or with the PublishInlines
+// transaction of differential.revision.edit.
+func (c *Conn) DifferentialCreateInline(
+	req requests.DifferentialCreateInlineRequest,
+) (*responses.DifferentialCreateInlineResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialCreateInlineContext(ctx, req)
+}
+
+// DifferentialCreateInlineContext performs a call to
+// differential.createinline, passing through the given context.
+func (c *Conn) DifferentialCreateInlineContext(
+	ctx context.Context,
+	req requests.DifferentialCreateInlineRequest,
+) (*responses.DifferentialCreateInlineResponse, error) {
+	var res responses.DifferentialCreateInlineResponse
+
+	if err := c.CallContext(
+		ctx, DifferentialCreateInlineMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// DifferentialCreateCommentMethod is method name on Phabricator API.
+const DifferentialCreateCommentMethod = "differential.createcomment"
+
+// DifferentialCreateComment performs a call to differential.createcomment.
+func (c *Conn) DifferentialCreateComment(
+	req requests.DifferentialCreateCommentRequest,
+) (*responses.DifferentialCreateCommentResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialCreateCommentContext(ctx, req)
+}
+
+// DifferentialCreateCommentContext performs a call to
+// differential.createcomment, passing through the given context.
+func (c *Conn) DifferentialCreateCommentContext(
+	ctx context.Context,
+	req requests.DifferentialCreateCommentRequest,
+) (*responses.DifferentialCreateCommentResponse, error) {
+	var res responses.DifferentialCreateCommentResponse
+
+	if err := c.CallContext(
+		ctx, DifferentialCreateCommentMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// DifferentialPostInlineComments drafts the inline comments on the revision
+// and publishes them together, along with message as a summary comment if it
+// is not empty. The RevisionID of each inline comment is set to revisionID.
+//
+// The drafts are published with differential.createcomment, since
+// differential.createinline does not return the PHIDs which the inline
+// transaction of differential.revision.edit needs. Drafts whose PHIDs are
+// known can be published with DifferentialPublishInlineComments instead.
+//
+// Inline comments drafted before a failure stay drafts of the user and are
+// published with its next comment on the revision.
+func (c *Conn) DifferentialPostInlineComments(
+	revisionID int,
+	inlines []requests.DifferentialCreateInlineRequest,
+	message string,
+) (*responses.DifferentialCreateCommentResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialPostInlineCommentsContext(
+		ctx,
+		revisionID,
+		inlines,
+		message,
+	)
+}
+
+// DifferentialPostInlineCommentsContext drafts and publishes inline comments
+// like DifferentialPostInlineComments, passing through the given context.
+func (c *Conn) DifferentialPostInlineCommentsContext(
+	ctx context.Context,
+	revisionID int,
+	inlines []requests.DifferentialCreateInlineRequest,
+	message string,
+) (*responses.DifferentialCreateCommentResponse, error) {
+	for _, inline := range inlines {
+		inline.RevisionID = revisionID
+		if _, err := c.DifferentialCreateInlineContext(ctx, inline); err != nil {
+			return nil, err
+		}
+	}
+
+	return c.DifferentialCreateCommentContext(
+		ctx,
+		requests.DifferentialCreateCommentRequest{
+			RevisionID:    revisionID,
+			Message:       message,
+			AttachInlines: true,
+		},
+	)
+}
+
+// DifferentialPublishInlineComments publishes the draft inline comments with
+// the given PHIDs through differential.revision.edit, along with message as a
+// summary comment if it is not empty. The revision is identified by its ID,
+// PHID or monogram, such as "D123".
+func (c *Conn) DifferentialPublishInlineComments(
+	revision string,
+	inlinePHIDs []string,
+	message string,
+) (*responses.EditResponse, error) {
+	ctx := context.Background()
+	return c.DifferentialPublishInlineCommentsContext(
+		ctx,
+		revision,
+		inlinePHIDs,
+		message,
+	)
+}
+
+// DifferentialPublishInlineCommentsContext publishes draft inline comments
+// like DifferentialPublishInlineComments, passing through the given context.
+func (c *Conn) DifferentialPublishInlineCommentsContext(
+	ctx context.Context,
+	revision string,
+	inlinePHIDs []string,
+	message string,
+) (*responses.EditResponse, error) {
+	req := requests.DifferentialRevisionEditRequest{ObjectIdentifier: revision}
+	req.PublishInlines(inlinePHIDs...)
+	if message != "" {
+		req.AddComment(message)
+	}
+
+	return c.DifferentialRevisionEditContext(ctx, req)
+}
+
+// DifferentialInlineComments returns the published inline comments of a
+// revision, read from transaction.search. The revision is identified by its
+// PHID or monogram, such as "D123".
+func (c *Conn) DifferentialInlineComments(
+	revision string,
+) ([]*responses.TransactionSearchResponseItem, error) {
+	ctx := context.Background()
+	return c.DifferentialInlineCommentsContext(ctx, revision)
+}
+
+// DifferentialInlineCommentsContext returns the published inline comments of
+// a revision like DifferentialInlineComments, passing through the given
+// context.
+func (c *Conn) DifferentialInlineCommentsContext(
+	ctx context.Context,
+	revision string,
+) ([]*responses.TransactionSearchResponseItem, error) {
+	xactions, err := c.TransactionSearchAll(
+		ctx,
+		requests.TransactionSearchRequest{ObjectIdentifier: revision},
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var inlines []*responses.TransactionSearchResponseItem
+	for _, xaction := range xactions {
+		if xaction.IsInline() {
+			inlines = append(inlines, xaction)
+		}
+	}
+
+	return inlines, nil
+}
+
+// DifferentialSetDiffPropertyMethod is method name on Phabricator API.
+const DifferentialSetDiffPropertyMethod = "differential.setdiffproperty"
+
+// DifferentialSetDiffProperty performs a call to
+// differential.setdiffproperty.
+func (c *Conn) DifferentialSetDiffProperty(
+	req requests.DifferentialSetDiffPropertyRequest,
+) error {
+	ctx := context.Background()
+	return c.DifferentialSetDiffPropertyContext(ctx, req)
+}
+
+// DifferentialSetDiffPropertyContext performs a call to
+// differential.setdiffproperty, passing through the given context.
+func (c *Conn) DifferentialSetDiffPropertyContext(
+	ctx context.Context,
+	req requests.DifferentialSetDiffPropertyRequest,
+) error {
+	return c.CallContext(ctx, DifferentialSetDiffPropertyMethod, &req, nil)
+}
+
+// DifferentialRevisionSearchIterator iterates over all results of differential.revision.search.
+type DifferentialRevisionSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *DifferentialRevisionSearchIterator) Item() *responses.DifferentialRevisionSearchResponseItem {
+	item, _ := it.item.(*responses.DifferentialRevisionSearchResponseItem)
+	return item
+}
+
+// DifferentialRevisionSearchIter returns an iterator over all results of
+// differential.revision.search, following the result cursor starting at
+// req.Cursor. If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) DifferentialRevisionSearchIter(
+	ctx context.Context,
+	req requests.DifferentialRevisionSearchRequest,
+	maxItems int,
+) *DifferentialRevisionSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.DifferentialRevisionSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &DifferentialRevisionSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// DifferentialRevisionSearchAll calls differential.revision.search as many
+// times as needed to collect all results, following the result cursor. If
+// maxItems is positive, at most maxItems results are returned.
+func (c *Conn) DifferentialRevisionSearchAll(
+	ctx context.Context,
+	req requests.DifferentialRevisionSearchRequest,
+	maxItems int,
+) ([]*responses.DifferentialRevisionSearchResponseItem, error) {
+	var items []*responses.DifferentialRevisionSearchResponseItem
+	it := c.DifferentialRevisionSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// DifferentialDiffSearchIterator iterates over all results of differential.diff.search.
+type DifferentialDiffSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *DifferentialDiffSearchIterator) Item() *responses.DifferentialDiffSearchResponseItem {
+	item, _ := it.item.(*responses.DifferentialDiffSearchResponseItem)
+	return item
+}
+
+// DifferentialDiffSearchIter returns an iterator over all results of
+// differential.diff.search, following the result cursor starting at req.Cursor.
+// If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) DifferentialDiffSearchIter(
+	ctx context.Context,
+	req requests.DifferentialDiffSearchRequest,
+	maxItems int,
+) *DifferentialDiffSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.DifferentialDiffSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &DifferentialDiffSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// DifferentialDiffSearchAll calls differential.diff.search as many times as
+// needed to collect all results, following the result cursor. If maxItems is
+// positive, at most maxItems results are returned.
+func (c *Conn) DifferentialDiffSearchAll(
+	ctx context.Context,
+	req requests.DifferentialDiffSearchRequest,
+	maxItems int,
+) ([]*responses.DifferentialDiffSearchResponseItem, error) {
+	var items []*responses.DifferentialDiffSearchResponseItem
+	it := c.DifferentialDiffSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// DifferentialChangesetSearchIterator iterates over all results of
+// differential.changeset.search.
+type DifferentialChangesetSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *DifferentialChangesetSearchIterator) Item() *responses.DifferentialChangesetSearchResponseItem {
+	item, _ := it.item.(*responses.DifferentialChangesetSearchResponseItem)
+	return item
+}
+
+// DifferentialChangesetSearchIter returns an iterator over all results of
+// differential.changeset.search, following the result cursor starting at
+// req.Cursor. If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) DifferentialChangesetSearchIter(
+	ctx context.Context,
+	req requests.DifferentialChangesetSearchRequest,
+	maxItems int,
+) *DifferentialChangesetSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.DifferentialChangesetSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &DifferentialChangesetSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// DifferentialChangesetSearchAll calls differential.changeset.search as many
+// times as needed to collect all results, following the result cursor. If
+// maxItems is positive, at most maxItems results are returned.
+func (c *Conn) DifferentialChangesetSearchAll(
+	ctx context.Context,
+	req requests.DifferentialChangesetSearchRequest,
+	maxItems int,
+) ([]*responses.DifferentialChangesetSearchResponseItem, error) {
+	var items []*responses.DifferentialChangesetSearchResponseItem
+	it := c.DifferentialChangesetSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
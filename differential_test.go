@@ -1,15 +1,20 @@
 package gonduit
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/constants"
 	"github.com/uber/gonduit/core"
 	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 	"github.com/uber/gonduit/test/server"
+	"github.com/uber/gonduit/unidiff"
 )
 
 func TestDifferentialGetCommitPaths(t *testing.T) {
@@ -405,3 +410,694 @@ func TestDifferentialDiffSearch(t *testing.T) {
 	}
 	assert.Equal(t, &want, resp)
 }
+
+func TestDifferentialQueryContext_withDeadline(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterSlowMethod(
+		DifferentialQueryMethod,
+		http.StatusOK,
+		time.Minute,
+		server.ResponseFromJSON(`{"result": []}`),
+	)
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	_, err = c.DifferentialQueryContext(ctx, requests.DifferentialQueryRequest{
+		IDs: []uint64{123},
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, time.Since(started) < time.Minute)
+}
+
+func TestDifferentialRevisionEdit(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(DifferentialRevisionEditMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(editResponseJSON)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	req := requests.DifferentialRevisionEditRequest{ObjectIdentifier: "D123"}
+	req.Update("PHID-DIFF-1").
+		SetTitle("Fix the build").
+		SetSummary("It was broken.").
+		SetTestPlan("Ran the tests.").
+		SetRepository("PHID-REPO-1").
+		AddReviewers("PHID-USER-1").
+		AddBlockingReviewers("PHID-USER-2").
+		RemoveReviewers("PHID-USER-3").
+		SetReviewers("PHID-USER-4").
+		AddProjects("PHID-PROJ-1").
+		RemoveProjects("PHID-PROJ-2").
+		SetProjects("PHID-PROJ-3").
+		AddSubscribers("PHID-USER-5").
+		RemoveSubscribers("PHID-USER-6").
+		SetSubscribers("PHID-USER-7").
+		AddTasks("PHID-TASK-1").
+		RemoveTasks("PHID-TASK-2").
+		SetTasks("PHID-TASK-3").
+		AddParents("PHID-DREV-1").
+		RemoveParents("PHID-DREV-2").
+		SetParents("PHID-DREV-3").
+		AddChildren("PHID-DREV-4").
+		RemoveChildren("PHID-DREV-5").
+		SetChildren("PHID-DREV-6").
+		SetViewPolicy("users").
+		SetEditPolicy("admin").
+		AddComment("LGTM").
+		PublishInlines("PHID-XCMT-1").
+		Accept().
+		Reject().
+		RequestReview().
+		PlanChanges().
+		Resign().
+		Commandeer().
+		Abandon().
+		Reclaim().
+		Close().
+		Reopen().
+		SetDraft(false)
+
+	res, err := c.DifferentialRevisionEdit(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 123, res.Object.ID)
+	assert.Len(t, res.Transactions, 2)
+
+	assert.Equal(t, "D123", params["objectIdentifier"])
+
+	var types []string
+	values := map[string]interface{}{}
+	for _, transaction := range params["transactions"].([]interface{}) {
+		transaction := transaction.(map[string]interface{})
+		types = append(types, transaction["type"].(string))
+		values[transaction["type"].(string)] = transaction["value"]
+	}
+
+	assert.Equal(t, []string{
+		"update", "title", "summary", "testPlan", "repositoryPHID",
+		"reviewers.add", "reviewers.add", "reviewers.remove", "reviewers.set",
+		"projects.add", "projects.remove", "projects.set",
+		"subscribers.add", "subscribers.remove", "subscribers.set",
+		"tasks.add", "tasks.remove", "tasks.set",
+		"parents.add", "parents.remove", "parents.set",
+		"children.add", "children.remove", "children.set",
+		"view", "edit", "comment", "inline",
+		"accept", "reject", "request-review", "plan-changes", "resign",
+		"commandeer", "abandon", "reclaim", "close", "reopen", "draft",
+	}, types)
+
+	assert.Equal(t, "PHID-DIFF-1", values["update"])
+	assert.Equal(t, []interface{}{"PHID-USER-4"}, values["reviewers.set"])
+	assert.Equal(t, true, values["accept"])
+	assert.Equal(t, false, values["draft"])
+	assert.Equal(
+		t,
+		map[string]interface{}{
+			"type":  "reviewers.add",
+			"value": []interface{}{"blocking(PHID-USER-2)"},
+		},
+		params["transactions"].([]interface{})[6],
+	)
+}
+
+func TestDifferentialRevisionEdit_withError(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod(
+		DifferentialRevisionEditMethod,
+		http.StatusOK,
+		server.ResponseFromJSON(`{
+			"error_code": "ERR-CONDUIT-CORE",
+			"error_info": "Transaction has no effect."
+		}`),
+	)
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	req := requests.DifferentialRevisionEditRequest{ObjectIdentifier: "D123"}
+	_, err = c.DifferentialRevisionEdit(*req.Accept())
+
+	assert.True(t, core.IsConduitError(err))
+}
+
+func TestDifferentialCreateRawDiff(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(DifferentialCreateRawDiffMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": {
+				"id": 1234,
+				"phid": "PHID-DIFF-xxxxxxxxxxxxxxxxxxxx",
+				"uri": "https://phabricator.example.com/differential/diff/1234/"
+			}
+		}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	res, err := c.DifferentialCreateRawDiff(
+		requests.DifferentialCreateRawDiffRequest{
+			Diff:           "--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-a\n+b\n",
+			RepositoryPHID: "PHID-REPO-1",
+		},
+	)
+	assert.Nil(t, err)
+
+	assert.Equal(t, &responses.DifferentialCreateRawDiffResponse{
+		ID:   1234,
+		PHID: "PHID-DIFF-xxxxxxxxxxxxxxxxxxxx",
+		URI:  "https://phabricator.example.com/differential/diff/1234/",
+	}, res)
+	assert.Equal(t, "PHID-REPO-1", params["repositoryPHID"])
+	assert.NotContains(t, params, "viewPolicy")
+}
+
+func TestDifferentialCreateDiff(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(DifferentialCreateDiffMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": {
+				"diffid": 1235,
+				"phid": "PHID-DIFF-yyyyyyyyyyyyyyyyyyyy",
+				"uri": "https://phabricator.example.com/differential/diff/1235/"
+			}
+		}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	changes, err := unidiff.ParseString(
+		"diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n" +
+			"@@ -1 +1 @@\n-a\n+b\n",
+	)
+	assert.Nil(t, err)
+
+	res, err := c.DifferentialCreateDiff(requests.DifferentialCreateDiffRequest{
+		Changes:                   changes,
+		SourceMachine:             "ci",
+		SourcePath:                "/src",
+		Branch:                    "master",
+		SourceControlSystem:       "git",
+		SourceControlPath:         "/",
+		SourceControlBaseRevision: "0123456789abcdef",
+		LintStatus:                constants.DifferentialLintStatusSkip,
+		UnitStatus:                constants.DifferentialUnitStatusPostponed,
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, &responses.DifferentialCreateDiffResponse{
+		ID:   1235,
+		PHID: "PHID-DIFF-yyyyyyyyyyyyyyyyyyyy",
+		URI:  "https://phabricator.example.com/differential/diff/1235/",
+	}, res)
+
+	assert.Equal(t, "skip", params["lintStatus"])
+	assert.Equal(t, "postponed", params["unitStatus"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"metadata":      map[string]interface{}{},
+			"oldPath":       "a.txt",
+			"currentPath":   "a.txt",
+			"awayPaths":     []interface{}{},
+			"oldProperties": map[string]interface{}{},
+			"newProperties": map[string]interface{}{},
+			"type":          float64(2),
+			"fileType":      float64(1),
+			"hunks": []interface{}{
+				map[string]interface{}{
+					"oldOffset":           float64(1),
+					"oldLength":           float64(1),
+					"newOffset":           float64(1),
+					"newLength":           float64(1),
+					"addLines":            float64(1),
+					"delLines":            float64(1),
+					"isMissingOldNewline": false,
+					"isMissingNewNewline": false,
+					"corpus":              "-a\n+b\n",
+				},
+			},
+		},
+	}, params["changes"])
+}
+
+func TestDifferentialGetRawDiff(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(DifferentialGetRawDiffMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": "diff --git a/a.txt b/a.txt\n--- a/a.txt\n+++ b/a.txt\n@@ -1 +1 @@\n-a\n+b\n"
+		}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	res, err := c.DifferentialGetRawDiff(requests.DifferentialGetRawDiffRequest{
+		DiffID: 1234,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1234), params["diffID"])
+
+	files, err := unidiff.ParseFiles(string(*res))
+	assert.Nil(t, err)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, "a.txt", files[0].NewPath)
+		assert.Equal(t, []unidiff.Line{
+			{Kind: unidiff.LineAdded, Text: "b", NewNumber: 1},
+		}, files[0].AddedLines())
+	}
+}
+
+func TestDifferentialChangesetSearch(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod(
+		DifferentialChangesetSearchMethod,
+		http.StatusOK,
+		server.ResponseFromJSON(`{
+			"result": {
+				"data": [
+					{
+						"id": 4321,
+						"type": "DCTN",
+						"phid": "PHID-DCTN-xxxxxxxxxxxxxxxxxxxx",
+						"fields": {
+							"diffPHID": "PHID-DIFF-xxxxxxxxxxxxxxxxxxxx",
+							"path": {"displayPath": "src/main.go"},
+							"dateCreated": 1419993553,
+							"dateModified": 1419994281
+						},
+						"attachments": {}
+					}
+				],
+				"maps": {},
+				"query": {"queryKey": null},
+				"cursor": {"limit": 100, "after": null, "before": null, "order": null}
+			}
+		}`),
+	)
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	res, err := c.DifferentialChangesetSearch(
+		requests.DifferentialChangesetSearchRequest{
+			Constraints: &requests.DifferentialChangesetSearchConstraints{
+				DiffPHIDs: []string{"PHID-DIFF-xxxxxxxxxxxxxxxxxxxx"},
+			},
+		},
+	)
+	assert.Nil(t, err)
+
+	if assert.Len(t, res.Data, 1) {
+		item := res.Data[0]
+		assert.Equal(t, 4321, item.ID)
+		assert.Equal(t, "PHID-DCTN-xxxxxxxxxxxxxxxxxxxx", item.PHID)
+		assert.Equal(t, responses.DifferentialChangesetSearchResponseItemFields{
+			DiffPHID:     "PHID-DIFF-xxxxxxxxxxxxxxxxxxxx",
+			Path:         responses.DifferentialChangesetPath{DisplayPath: "src/main.go"},
+			DateCreated:  timestamp(1419993553),
+			DateModified: timestamp(1419994281),
+		}, item.Fields)
+	}
+
+	items, err := c.DifferentialChangesetSearchAll(
+		context.Background(),
+		requests.DifferentialChangesetSearchRequest{},
+		0,
+	)
+	assert.Nil(t, err)
+	assert.Len(t, items, 1)
+}
+
+func TestDifferentialPostInlineComments(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var inlines []map[string]interface{}
+	s.RegisterMethodFunc(DifferentialCreateInlineMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		inlines = append(inlines, p)
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": {
+				"id": 42,
+				"authorPHID": "PHID-USER-1",
+				"filePath": "a.txt",
+				"isNewFile": true,
+				"lineNumber": 3,
+				"lineLength": 1,
+				"diffID": 7,
+				"content": "nit"
+			}
+		}`)
+	})
+
+	var comment map[string]interface{}
+	s.RegisterMethodFunc(DifferentialCreateCommentMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		comment = p
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": {"revisionid": 123, "uri": "https://phab.example.com/D123"}
+		}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	res, err := c.DifferentialPostInlineCommentsContext(
+		context.Background(),
+		123,
+		[]requests.DifferentialCreateInlineRequest{
+			{
+				FilePath:   "a.txt",
+				IsNewFile:  true,
+				LineNumber: 3,
+				LineLength: 1,
+				Content:    "nit",
+			},
+			{
+				DiffID:     7,
+				FilePath:   "b.txt",
+				LineNumber: 10,
+				Content:    "removed too much",
+			},
+		},
+		"lint found 2 issues",
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, &responses.DifferentialCreateCommentResponse{
+		RevisionID: 123,
+		URI:        "https://phab.example.com/D123",
+	}, res)
+
+	if assert.Len(t, inlines, 2) {
+		assert.Equal(t, float64(123), inlines[0]["revisionID"])
+		assert.Equal(t, "a.txt", inlines[0]["filePath"])
+		assert.Equal(t, true, inlines[0]["isNewFile"])
+		assert.Equal(t, float64(3), inlines[0]["lineNumber"])
+		assert.Equal(t, float64(1), inlines[0]["lineLength"])
+		assert.NotContains(t, inlines[0], "diffID")
+
+		assert.Equal(t, float64(123), inlines[1]["revisionID"])
+		assert.Equal(t, float64(7), inlines[1]["diffID"])
+		assert.Equal(t, false, inlines[1]["isNewFile"])
+		assert.NotContains(t, inlines[1], "lineLength")
+	}
+
+	assert.Equal(t, float64(123), comment["revision_id"])
+	assert.Equal(t, "lint found 2 issues", comment["message"])
+	assert.Equal(t, true, comment["attach_inlines"])
+	assert.NotContains(t, comment, "action")
+}
+
+func TestDifferentialPostInlineComments_withError(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod(DifferentialCreateInlineMethod, http.StatusOK, server.ResponseFromJSON(`{
+		"error_code": "ERR-CONDUIT-CORE",
+		"error_info": "Line number is required."
+	}`))
+
+	published := false
+	s.RegisterMethodFunc(DifferentialCreateCommentMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		published = true
+		return http.StatusOK, server.ResponseFromJSON(`{"result": {}}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	_, err = c.DifferentialPostInlineComments(
+		123,
+		[]requests.DifferentialCreateInlineRequest{{FilePath: "a.txt"}},
+		"",
+	)
+	assert.NotNil(t, err)
+	assert.False(t, published)
+}
+
+func TestDifferentialPublishInlineComments(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(DifferentialRevisionEditMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(editResponseJSON)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	res, err := c.DifferentialPublishInlineCommentsContext(
+		context.Background(),
+		"D123",
+		[]string{"PHID-XCMT-1", "PHID-XCMT-2"},
+		"lint found 2 issues",
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, 123, res.Object.ID)
+
+	assert.Equal(t, "D123", params["objectIdentifier"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"type":  "inline",
+			"value": []interface{}{"PHID-XCMT-1", "PHID-XCMT-2"},
+		},
+		map[string]interface{}{
+			"type":  "comment",
+			"value": "lint found 2 issues",
+		},
+	}, params["transactions"])
+
+	_, err = c.DifferentialPublishInlineComments(
+		"D123",
+		[]string{"PHID-XCMT-3"},
+		"",
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"type":  "inline",
+			"value": []interface{}{"PHID-XCMT-3"},
+		},
+	}, params["transactions"])
+}
+
+func TestDifferentialCreateComment(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(DifferentialCreateCommentMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": {"revisionid": 123, "uri": "https://phab.example.com/D123"}
+		}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	res, err := c.DifferentialCreateComment(requests.DifferentialCreateCommentRequest{
+		RevisionID: 123,
+		Message:    "LGTM",
+		Action:     constants.DifferentialCommentActionAccept,
+		Silent:     true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 123, res.RevisionID)
+	assert.Equal(t, "accept", params["action"])
+	assert.Equal(t, true, params["silent"])
+	assert.NotContains(t, params, "attach_inlines")
+}
+
+func TestDifferentialInlineComments(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(TransactionSearchMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": {
+				"data": [
+					{
+						"id": 1,
+						"phid": "PHID-XACT-DREV-1",
+						"type": "comment",
+						"comments": [],
+						"fields": []
+					},
+					{
+						"id": 2,
+						"phid": "PHID-XACT-DREV-2",
+						"type": "inline",
+						"authorPHID": "PHID-USER-1",
+						"comments": [
+							{
+								"id": 9,
+								"phid": "PHID-XCMT-9",
+								"content": {"raw": "nit"}
+							}
+						],
+						"fields": {
+							"diff": {"id": 7, "phid": "PHID-DIFF-7"},
+							"path": "a.txt",
+							"line": 3,
+							"length": 2,
+							"replyToCommentPHID": "PHID-XCMT-8",
+							"isDone": true
+						}
+					}
+				],
+				"cursor": {"limit": 100, "after": null, "before": null}
+			}
+		}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	inlines, err := c.DifferentialInlineCommentsContext(
+		context.Background(),
+		"D123",
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, "D123", params["objectIdentifier"])
+
+	if assert.Len(t, inlines, 1) {
+		assert.True(t, inlines[0].IsInline())
+		assert.Equal(t, "PHID-XACT-DREV-2", inlines[0].PHID)
+		assert.Equal(t, responses.TransactionSearchResponseItemFields{
+			Diff: &responses.TransactionSearchResponseItemFieldsDiff{
+				ID:   7,
+				PHID: "PHID-DIFF-7",
+			},
+			Path:               "a.txt",
+			Line:               3,
+			Length:             2,
+			ReplyToCommentPHID: "PHID-XCMT-8",
+			IsDone:             true,
+		}, inlines[0].Fields)
+		assert.Equal(t, "nit", inlines[0].Comments[0].Content.Raw)
+	}
+}
+
+func TestDifferentialSetDiffProperty(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(DifferentialSetDiffPropertyMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{"result": null}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	req := requests.DifferentialSetDiffPropertyRequest{
+		DiffID: 1234,
+		Name:   constants.DifferentialDiffPropertyUnit,
+	}
+	assert.Nil(t, req.SetValue([]*requests.HarbormasterUnitResult{
+		{Name: "TestParse", Result: constants.HarbormasterUnitResultPass},
+	}))
+
+	err = c.DifferentialSetDiffProperty(req)
+	assert.Nil(t, err)
+	assert.Equal(t, float64(1234), params["diff_id"])
+	assert.Equal(t, "arc:unit", params["name"])
+	assert.Equal(t, `[{"name":"TestParse","result":"pass"}]`, params["data"])
+}
+
+func TestDifferentialSetDiffPropertyRequest_SetValue_withError(t *testing.T) {
+	req := requests.DifferentialSetDiffPropertyRequest{}
+
+	assert.NotNil(t, req.SetValue(make(chan int)))
+	assert.Equal(t, "", req.Data)
+}
@@ -1,6 +1,9 @@
 package gonduit
 
 import (
+	"context"
+
+	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -11,10 +14,21 @@ const DiffusionQueryCommitsMethod = "diffusion.querycommits"
 // DiffusionQueryCommits performs a call to diffusion.querycommits.
 func (c *Conn) DiffusionQueryCommits(
 	req requests.DiffusionQueryCommitsRequest,
+) (*responses.DiffusionQueryCommitsResponse, error) {
+	ctx := context.Background()
+	return c.DiffusionQueryCommitsContext(ctx, req)
+}
+
+// DiffusionQueryCommitsContext performs a call to diffusion.querycommits,
+// passing through the given context.
+func (c *Conn) DiffusionQueryCommitsContext(
+	ctx context.Context,
+	req requests.DiffusionQueryCommitsRequest,
 ) (*responses.DiffusionQueryCommitsResponse, error) {
 	var res responses.DiffusionQueryCommitsResponse
 
-	if err := c.Call(DiffusionQueryCommitsMethod, &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, DiffusionQueryCommitsMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -28,10 +42,80 @@ const DiffusionRepositorySearchMethod = "diffusion.repository.search"
 // method.
 func (c *Conn) DiffusionRepositorySearch(
 	req requests.DiffusionRepositorySearchRequest,
+) (*responses.DiffusionRepositorySearchResponse, error) {
+	ctx := context.Background()
+	return c.DiffusionRepositorySearchContext(ctx, req)
+}
+
+// DiffusionRepositorySearchContext calls "diffusion.repository.search" Conduit API
+// method.
+func (c *Conn) DiffusionRepositorySearchContext(
+	ctx context.Context,
+	req requests.DiffusionRepositorySearchRequest,
 ) (*responses.DiffusionRepositorySearchResponse, error) {
 	var resp responses.DiffusionRepositorySearchResponse
-	if err := c.Call(DiffusionRepositorySearchMethod, &req, &resp); err != nil {
+	if err := c.CallContext(
+		ctx, DiffusionRepositorySearchMethod, &req, &resp); err != nil {
 		return nil, err
 	}
 	return &resp, nil
 }
+
+// DiffusionRepositorySearchIterator iterates over all results of diffusion.repository.search.
+type DiffusionRepositorySearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *DiffusionRepositorySearchIterator) Item() *responses.DiffusionRepositorySearchResponseItem {
+	item, _ := it.item.(*responses.DiffusionRepositorySearchResponseItem)
+	return item
+}
+
+// DiffusionRepositorySearchIter returns an iterator over all results of
+// diffusion.repository.search, following the result cursor starting at
+// req.Cursor. If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) DiffusionRepositorySearchIter(
+	ctx context.Context,
+	req requests.DiffusionRepositorySearchRequest,
+	maxItems int,
+) *DiffusionRepositorySearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.DiffusionRepositorySearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &DiffusionRepositorySearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// DiffusionRepositorySearchAll calls diffusion.repository.search as many times
+// as needed to collect all results, following the result cursor. If maxItems is
+// positive, at most maxItems results are returned.
+func (c *Conn) DiffusionRepositorySearchAll(
+	ctx context.Context,
+	req requests.DiffusionRepositorySearchRequest,
+	maxItems int,
+) ([]*responses.DiffusionRepositorySearchResponseItem, error) {
+	var items []*responses.DiffusionRepositorySearchResponseItem
+	it := c.DiffusionRepositorySearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,6 +1,9 @@
 package gonduit
 
 import (
+	"context"
+
+	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -10,12 +13,80 @@ const EdgeSearchMethod = "edge.search"
 // EdgeSearch performs a call to edge.search.
 func (c *Conn) EdgeSearch(
 	req requests.EdgeSearchRequest,
+) (*responses.EdgeSearchResponse, error) {
+	ctx := context.Background()
+	return c.EdgeSearchContext(ctx, req)
+}
+
+// EdgeSearchContext performs a call to edge.search, passing through the given
+// context.
+func (c *Conn) EdgeSearchContext(
+	ctx context.Context,
+	req requests.EdgeSearchRequest,
 ) (*responses.EdgeSearchResponse, error) {
 	var res responses.EdgeSearchResponse
 
-	if err := c.Call(EdgeSearchMethod, &req, &res); err != nil {
+	if err := c.CallContext(ctx, EdgeSearchMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// EdgeSearchIterator iterates over all results of edge.search.
+type EdgeSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current edge. It is only valid after a call to Next
+// returned true.
+func (it *EdgeSearchIterator) Item() entities.Edge {
+	item, _ := it.item.(entities.Edge)
+	return item
+}
+
+// EdgeSearchIter returns an iterator over all results of edge.search, following
+// the result cursor starting at req.Cursor. If maxItems is positive, at most
+// maxItems results are returned.
+func (c *Conn) EdgeSearchIter(
+	ctx context.Context,
+	req requests.EdgeSearchRequest,
+	maxItems int,
+) *EdgeSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.EdgeSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		return items, res.Cursor.After, nil
+	}
+
+	return &EdgeSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// EdgeSearchAll calls edge.search as many times as needed to collect all edges,
+// following the result cursor. If maxItems is positive, at most maxItems edges
+// are returned.
+func (c *Conn) EdgeSearchAll(
+	ctx context.Context,
+	req requests.EdgeSearchRequest,
+	maxItems int,
+) ([]entities.Edge, error) {
+	var items []entities.Edge
+	it := c.EdgeSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,8 +1,11 @@
 package entities
 
 // Cursor represents the pagination cursor on many responses.
+//
+// Zero values are omitted when a cursor is sent as part of a request so that
+// the server applies its defaults instead of paging after/before ID 0.
 type Cursor struct {
-	Limit  uint64 `json:"limit"`
-	After  uint64 `json:"after"`
-	Before uint64 `json:"before"`
-}
\ No newline at end of file
+	Limit  uint64 `json:"limit,omitempty"`
+	After  uint64 `json:"after,omitempty"`
+	Before uint64 `json:"before,omitempty"`
+}
@@ -0,0 +1,44 @@
+package entities
+
+import "github.com/uber/gonduit/constants"
+
+// DifferentialChange is a changed path of a diff, in the structure sent to
+// differential.creatediff.
+type DifferentialChange struct {
+	Metadata      map[string]interface{}           `json:"metadata"`
+	OldPath       string                           `json:"oldPath"`
+	CurrentPath   string                           `json:"currentPath"`
+	AwayPaths     []string                         `json:"awayPaths"`
+	OldProperties map[string]string                `json:"oldProperties"`
+	NewProperties map[string]string                `json:"newProperties"`
+	Type          constants.DifferentialChangeType `json:"type"`
+	FileType      constants.DifferentialFileType   `json:"fileType"`
+	CommitHash    string                           `json:"commitHash,omitempty"`
+	Hunks         []*DifferentialHunk              `json:"hunks"`
+}
+
+// NewDifferentialChange creates an empty change. Its maps and slices are
+// initialized, since Phabricator does not accept null values for them.
+func NewDifferentialChange() *DifferentialChange {
+	return &DifferentialChange{
+		Metadata:      map[string]interface{}{},
+		AwayPaths:     []string{},
+		OldProperties: map[string]string{},
+		NewProperties: map[string]string{},
+		Hunks:         []*DifferentialHunk{},
+	}
+}
+
+// DifferentialHunk is a hunk of a changed file. Corpus holds the lines of the
+// hunk, prefixed with " ", "+" or "-" like in a unified diff.
+type DifferentialHunk struct {
+	OldOffset           int    `json:"oldOffset"`
+	OldLength           int    `json:"oldLength"`
+	NewOffset           int    `json:"newOffset"`
+	NewLength           int    `json:"newLength"`
+	AddLines            int    `json:"addLines"`
+	DelLines            int    `json:"delLines"`
+	IsMissingOldNewline bool   `json:"isMissingOldNewline"`
+	IsMissingNewNewline bool   `json:"isMissingNewNewline"`
+	Corpus              string `json:"corpus"`
+}
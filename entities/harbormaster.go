@@ -13,3 +13,87 @@ var (
 	// BuildableStatusFailed - some builds of buildable have failed.
 	BuildableStatusFailed = "failed"
 )
+
+// BuildStatus is the status of a Harbormaster build.
+type BuildStatus string
+
+const (
+	// BuildStatusInactive - build has not been started yet.
+	BuildStatusInactive BuildStatus = "inactive"
+	// BuildStatusPending - build is waiting to run.
+	BuildStatusPending BuildStatus = "pending"
+	// BuildStatusBuilding - build is running.
+	BuildStatusBuilding BuildStatus = "building"
+	// BuildStatusPassed - build has passed.
+	BuildStatusPassed BuildStatus = "passed"
+	// BuildStatusFailed - build has failed.
+	BuildStatusFailed BuildStatus = "failed"
+	// BuildStatusAborted - build was aborted.
+	BuildStatusAborted BuildStatus = "aborted"
+	// BuildStatusError - build encountered an unexpected error.
+	BuildStatusError BuildStatus = "error"
+	// BuildStatusPaused - build was paused.
+	BuildStatusPaused BuildStatus = "paused"
+	// BuildStatusDeadlocked - build steps can not make progress.
+	BuildStatusDeadlocked BuildStatus = "deadlocked"
+)
+
+// IsComplete returns true if the build has stopped and will not make any
+// further progress unless it is restarted.
+func (s BuildStatus) IsComplete() bool {
+	switch s {
+	case BuildStatusPassed,
+		BuildStatusFailed,
+		BuildStatusAborted,
+		BuildStatusError,
+		BuildStatusDeadlocked:
+		return true
+	default:
+		return false
+	}
+}
+
+// BuildTargetStatus is the status of a Harbormaster build target, a single
+// step of a build.
+type BuildTargetStatus string
+
+const (
+	// BuildTargetStatusPending - target is waiting to run.
+	BuildTargetStatusPending BuildTargetStatus = "target/pending"
+	// BuildTargetStatusBuilding - target is running.
+	BuildTargetStatusBuilding BuildTargetStatus = "target/building"
+	// BuildTargetStatusWaiting - target waits for a message, e.g. from an
+	// external build system.
+	BuildTargetStatusWaiting BuildTargetStatus = "target/waiting"
+	// BuildTargetStatusPassed - target has passed.
+	BuildTargetStatusPassed BuildTargetStatus = "target/passed"
+	// BuildTargetStatusFailed - target has failed.
+	BuildTargetStatusFailed BuildTargetStatus = "target/failed"
+	// BuildTargetStatusAborted - target was aborted.
+	BuildTargetStatusAborted BuildTargetStatus = "target/aborted"
+)
+
+// BuildPlanStatus is the status of a Harbormaster build plan.
+type BuildPlanStatus string
+
+const (
+	// BuildPlanStatusActive - plan can be run.
+	BuildPlanStatusActive BuildPlanStatus = "active"
+	// BuildPlanStatusDisabled - plan was disabled.
+	BuildPlanStatusDisabled BuildPlanStatus = "disabled"
+)
+
+// ArtifactType is the type of a Harbormaster build artifact.
+type ArtifactType string
+
+const (
+	// ArtifactTypeURI - artifact is a link, with "uri", "name" and
+	// "ui.external" data.
+	ArtifactTypeURI ArtifactType = "uri"
+	// ArtifactTypeFile - artifact is a file, with "filePHID" data.
+	ArtifactTypeFile ArtifactType = "file"
+	// ArtifactTypeHost - artifact is a Drydock host lease.
+	ArtifactTypeHost ArtifactType = "host"
+	// ArtifactTypeWorkingCopy - artifact is a Drydock working copy lease.
+	ArtifactTypeWorkingCopy ArtifactType = "working-copy"
+)
@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -8,10 +10,20 @@ import (
 // FileDownload performs a call to file.download.
 func (c *Conn) FileDownload(
 	req requests.FileDownloadRequest,
+) (*responses.FileDownloadResponse, error) {
+	ctx := context.Background()
+	return c.FileDownloadContext(ctx, req)
+}
+
+// FileDownloadContext performs a call to file.download, passing through the
+// given context.
+func (c *Conn) FileDownloadContext(
+	ctx context.Context,
+	req requests.FileDownloadRequest,
 ) (*responses.FileDownloadResponse, error) {
 	var res responses.FileDownloadResponse
 
-	if err := c.Call("file.download", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "file.download", &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,6 @@
 module github.com/uber/gonduit
 
-go 1.11
+go 1.13
 
 require (
 	github.com/karlseguin/expect v1.0.7 // indirect
@@ -1,6 +1,12 @@
 package gonduit
 
 import (
+	"context"
+	"errors"
+
+	"github.com/uber/gonduit/constants"
+	"github.com/uber/gonduit/core"
+	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -11,12 +17,625 @@ const HarbormasterBuildableSearchMethod = "harbormaster.buildable.search"
 // HarbormasterBuildableSearch performs a call to harbormaster.builable.search.
 func (c *Conn) HarbormasterBuildableSearch(
 	req requests.HarbormasterBuildableSearchRequest,
+) (*responses.HarbormasterBuildableSearchResponse, error) {
+	ctx := context.Background()
+	return c.HarbormasterBuildableSearchContext(ctx, req)
+}
+
+// HarbormasterBuildableSearchContext performs a call to
+// harbormaster.builable.search, passing through the given context.
+func (c *Conn) HarbormasterBuildableSearchContext(
+	ctx context.Context,
+	req requests.HarbormasterBuildableSearchRequest,
 ) (*responses.HarbormasterBuildableSearchResponse, error) {
 	var res responses.HarbormasterBuildableSearchResponse
 
-	if err := c.Call(HarbormasterBuildableSearchMethod, &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, HarbormasterBuildableSearchMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// HarbormasterSendMessageMethod is method name on Phabricator API.
+const HarbormasterSendMessageMethod = "harbormaster.sendmessage"
+
+// HarbormasterSendMessage performs a call to harbormaster.sendmessage,
+// reporting the state and the unit and lint results of a build target. The
+// request is checked with Validate before it is sent.
+func (c *Conn) HarbormasterSendMessage(
+	req requests.HarbormasterSendMessageRequest,
+) error {
+	ctx := context.Background()
+	return c.HarbormasterSendMessageContext(ctx, req)
+}
+
+// HarbormasterSendMessageContext performs a call to
+// harbormaster.sendmessage, passing through the given context.
+func (c *Conn) HarbormasterSendMessageContext(
+	ctx context.Context,
+	req requests.HarbormasterSendMessageRequest,
+) error {
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	return c.CallContext(ctx, HarbormasterSendMessageMethod, &req, nil)
+}
+
+// HarbormasterBuildSearchMethod is method name on Phabricator API.
+const HarbormasterBuildSearchMethod = "harbormaster.build.search"
+
+// HarbormasterBuildSearch performs a call to harbormaster.build.search.
+func (c *Conn) HarbormasterBuildSearch(
+	req requests.HarbormasterBuildSearchRequest,
+) (*responses.HarbormasterBuildSearchResponse, error) {
+	ctx := context.Background()
+	return c.HarbormasterBuildSearchContext(ctx, req)
+}
+
+// HarbormasterBuildSearchContext performs a call to harbormaster.build.search,
+// passing through the given context.
+func (c *Conn) HarbormasterBuildSearchContext(
+	ctx context.Context,
+	req requests.HarbormasterBuildSearchRequest,
+) (*responses.HarbormasterBuildSearchResponse, error) {
+	var res responses.HarbormasterBuildSearchResponse
+
+	if err := c.CallContext(
+		ctx, HarbormasterBuildSearchMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// HarbormasterBuildTargetSearchMethod is method name on Phabricator API.
+const HarbormasterBuildTargetSearchMethod = "harbormaster.target.search"
+
+// HarbormasterBuildTargetSearch performs a call to harbormaster.target.search.
+func (c *Conn) HarbormasterBuildTargetSearch(
+	req requests.HarbormasterBuildTargetSearchRequest,
+) (*responses.HarbormasterBuildTargetSearchResponse, error) {
+	ctx := context.Background()
+	return c.HarbormasterBuildTargetSearchContext(ctx, req)
+}
+
+// HarbormasterBuildTargetSearchContext performs a call to
+// harbormaster.target.search, passing through the given context.
+func (c *Conn) HarbormasterBuildTargetSearchContext(
+	ctx context.Context,
+	req requests.HarbormasterBuildTargetSearchRequest,
+) (*responses.HarbormasterBuildTargetSearchResponse, error) {
+	var res responses.HarbormasterBuildTargetSearchResponse
+
+	if err := c.CallContext(
+		ctx, HarbormasterBuildTargetSearchMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// HarbormasterBuildPlanSearchMethod is method name on Phabricator API.
+const HarbormasterBuildPlanSearchMethod = "harbormaster.buildplan.search"
+
+// HarbormasterBuildPlanSearch performs a call to harbormaster.buildplan.search.
+func (c *Conn) HarbormasterBuildPlanSearch(
+	req requests.HarbormasterBuildPlanSearchRequest,
+) (*responses.HarbormasterBuildPlanSearchResponse, error) {
+	ctx := context.Background()
+	return c.HarbormasterBuildPlanSearchContext(ctx, req)
+}
+
+// HarbormasterBuildPlanSearchContext performs a call to
+// harbormaster.buildplan.search, passing through the given context.
+func (c *Conn) HarbormasterBuildPlanSearchContext(
+	ctx context.Context,
+	req requests.HarbormasterBuildPlanSearchRequest,
+) (*responses.HarbormasterBuildPlanSearchResponse, error) {
+	var res responses.HarbormasterBuildPlanSearchResponse
+
+	if err := c.CallContext(
+		ctx, HarbormasterBuildPlanSearchMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// HarbormasterArtifactSearchMethod is method name on Phabricator API.
+const HarbormasterArtifactSearchMethod = "harbormaster.artifact.search"
+
+// HarbormasterArtifactSearch performs a call to harbormaster.artifact.search.
+func (c *Conn) HarbormasterArtifactSearch(
+	req requests.HarbormasterArtifactSearchRequest,
+) (*responses.HarbormasterArtifactSearchResponse, error) {
+	ctx := context.Background()
+	return c.HarbormasterArtifactSearchContext(ctx, req)
+}
+
+// HarbormasterArtifactSearchContext performs a call to
+// harbormaster.artifact.search, passing through the given context.
+func (c *Conn) HarbormasterArtifactSearchContext(
+	ctx context.Context,
+	req requests.HarbormasterArtifactSearchRequest,
+) (*responses.HarbormasterArtifactSearchResponse, error) {
+	var res responses.HarbormasterArtifactSearchResponse
+
+	if err := c.CallContext(
+		ctx, HarbormasterArtifactSearchMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// HarbormasterCreateArtifactMethod is method name on Phabricator API.
+const HarbormasterCreateArtifactMethod = "harbormaster.createartifact"
+
+// HarbormasterCreateArtifact performs a call to harbormaster.createartifact.
+func (c *Conn) HarbormasterCreateArtifact(
+	req requests.HarbormasterCreateArtifactRequest,
+) (*responses.HarbormasterCreateArtifactResponse, error) {
+	ctx := context.Background()
+	return c.HarbormasterCreateArtifactContext(ctx, req)
+}
+
+// HarbormasterCreateArtifactContext performs a call to
+// harbormaster.createartifact, passing through the given context.
+func (c *Conn) HarbormasterCreateArtifactContext(
+	ctx context.Context,
+	req requests.HarbormasterCreateArtifactRequest,
+) (*responses.HarbormasterCreateArtifactResponse, error) {
+	var res responses.HarbormasterCreateArtifactResponse
+
+	if err := c.CallContext(
+		ctx, HarbormasterCreateArtifactMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// HarbormasterBuildEditMethod is method name on Phabricator API.
+const HarbormasterBuildEditMethod = "harbormaster.build.edit"
+
+// HarbormasterBuildEdit performs a call to harbormaster.build.edit.
+func (c *Conn) HarbormasterBuildEdit(
+	req requests.HarbormasterBuildEditRequest,
+) (*responses.EditResponse, error) {
+	ctx := context.Background()
+	return c.HarbormasterBuildEditContext(ctx, req)
+}
+
+// HarbormasterBuildEditContext performs a call to harbormaster.build.edit,
+// passing through the given context.
+func (c *Conn) HarbormasterBuildEditContext(
+	ctx context.Context,
+	req requests.HarbormasterBuildEditRequest,
+) (*responses.EditResponse, error) {
+	var res responses.EditResponse
+
+	if err := c.CallContext(
+		ctx, HarbormasterBuildEditMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// HarbormasterCommandMethod is method name on Phabricator API.
+const HarbormasterCommandMethod = "harbormaster.command"
+
+// HarbormasterCommand performs a call to harbormaster.command. Servers which
+// support harbormaster.build.edit should use HarbormasterBuildEdit instead.
+func (c *Conn) HarbormasterCommand(
+	req requests.HarbormasterCommandRequest,
+) error {
+	ctx := context.Background()
+	return c.HarbormasterCommandContext(ctx, req)
+}
+
+// HarbormasterCommandContext performs a call to harbormaster.command,
+// passing through the given context.
+func (c *Conn) HarbormasterCommandContext(
+	ctx context.Context,
+	req requests.HarbormasterCommandRequest,
+) error {
+	return c.CallContext(ctx, HarbormasterCommandMethod, &req, nil)
+}
+
+// HarbormasterCommandBuildable issues the command to every build of the
+// buildable with the given PHID, such as one found by
+// HarbormasterBuildableSearch, through harbormaster.build.edit. Servers
+// without harbormaster.build.edit are sent harbormaster.command instead.
+// Builds the command does not apply to are skipped, e.g. completed builds when
+// aborting. It returns the PHIDs of the builds the command was issued to,
+// including those issued before an error.
+func (c *Conn) HarbormasterCommandBuildable(
+	buildablePHID string,
+	command constants.HarbormasterBuildCommand,
+) ([]string, error) {
+	ctx := context.Background()
+	return c.HarbormasterCommandBuildableContext(ctx, buildablePHID, command)
+}
+
+// HarbormasterCommandBuildableContext issues the command to every build of
+// the buildable like HarbormasterCommandBuildable, passing through the given
+// context.
+func (c *Conn) HarbormasterCommandBuildableContext(
+	ctx context.Context,
+	buildablePHID string,
+	command constants.HarbormasterBuildCommand,
+) ([]string, error) {
+	builds, err := c.HarbormasterBuildSearchAll(
+		ctx,
+		requests.HarbormasterBuildSearchRequest{
+			Constraints: &requests.HarbormasterBuildSearchConstraints{
+				Buildables: []string{buildablePHID},
+			},
+		},
+		0,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var commanded []string
+	useBuildEdit := true
+	for _, build := range builds {
+		if !canIssueBuildCommand(build.Fields.BuildStatus.Value, command) {
+			continue
+		}
+
+		if useBuildEdit {
+			req := requests.HarbormasterBuildEditRequest{
+				ObjectIdentifier: build.PHID,
+			}
+			req.Command(command)
+
+			_, err = c.HarbormasterBuildEditContext(ctx, req)
+			// Older servers only have the deprecated harbormaster.command.
+			useBuildEdit = !errors.Is(err, core.ErrNotFound)
+		}
+
+		if !useBuildEdit {
+			err = c.HarbormasterCommandContext(
+				ctx,
+				requests.HarbormasterCommandRequest{
+					Receiver: build.PHID,
+					Command:  command,
+				},
+			)
+		}
+
+		if err != nil {
+			return commanded, err
+		}
+
+		commanded = append(commanded, build.PHID)
+	}
+
+	return commanded, nil
+}
+
+// canIssueBuildCommand returns true if the command applies to a build with
+// the given status. Unknown commands are assumed to apply to every build.
+func canIssueBuildCommand(
+	status entities.BuildStatus,
+	command constants.HarbormasterBuildCommand,
+) bool {
+	switch command {
+	case constants.HarbormasterBuildCommandRestart:
+		return status.IsComplete()
+	case constants.HarbormasterBuildCommandPause:
+		return !status.IsComplete() && status != entities.BuildStatusPaused
+	case constants.HarbormasterBuildCommandResume:
+		return status == entities.BuildStatusPaused
+	case constants.HarbormasterBuildCommandAbort:
+		return !status.IsComplete()
+	default:
+		return true
+	}
+}
+
+// HarbormasterBuildableSearchIterator iterates over all results of harbormaster.buildable.search.
+type HarbormasterBuildableSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *HarbormasterBuildableSearchIterator) Item() *responses.HarbormasterBuildableSearchResponseItem {
+	item, _ := it.item.(*responses.HarbormasterBuildableSearchResponseItem)
+	return item
+}
+
+// HarbormasterBuildableSearchIter returns an iterator over all results of
+// harbormaster.buildable.search, following the result cursor starting at
+// req.Cursor. If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterBuildableSearchIter(
+	ctx context.Context,
+	req requests.HarbormasterBuildableSearchRequest,
+	maxItems int,
+) *HarbormasterBuildableSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.HarbormasterBuildableSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &HarbormasterBuildableSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// HarbormasterBuildableSearchAll calls harbormaster.buildable.search as many
+// times as needed to collect all results, following the result cursor. If
+// maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterBuildableSearchAll(
+	ctx context.Context,
+	req requests.HarbormasterBuildableSearchRequest,
+	maxItems int,
+) ([]*responses.HarbormasterBuildableSearchResponseItem, error) {
+	var items []*responses.HarbormasterBuildableSearchResponseItem
+	it := c.HarbormasterBuildableSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// HarbormasterBuildSearchIterator iterates over all results of
+// harbormaster.build.search.
+type HarbormasterBuildSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *HarbormasterBuildSearchIterator) Item() *responses.HarbormasterBuildSearchResponseItem {
+	item, _ := it.item.(*responses.HarbormasterBuildSearchResponseItem)
+	return item
+}
+
+// HarbormasterBuildSearchIter returns an iterator over all results of
+// harbormaster.build.search, following the result cursor starting at
+// req.Cursor. If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterBuildSearchIter(
+	ctx context.Context,
+	req requests.HarbormasterBuildSearchRequest,
+	maxItems int,
+) *HarbormasterBuildSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.HarbormasterBuildSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &HarbormasterBuildSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// HarbormasterBuildSearchAll calls harbormaster.build.search as many times as
+// needed to collect all results, following the result cursor. If maxItems is
+// positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterBuildSearchAll(
+	ctx context.Context,
+	req requests.HarbormasterBuildSearchRequest,
+	maxItems int,
+) ([]*responses.HarbormasterBuildSearchResponseItem, error) {
+	var items []*responses.HarbormasterBuildSearchResponseItem
+	it := c.HarbormasterBuildSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// HarbormasterBuildTargetSearchIterator iterates over all results of
+// harbormaster.target.search.
+type HarbormasterBuildTargetSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *HarbormasterBuildTargetSearchIterator) Item() *responses.HarbormasterBuildTargetSearchResponseItem {
+	item, _ := it.item.(*responses.HarbormasterBuildTargetSearchResponseItem)
+	return item
+}
+
+// HarbormasterBuildTargetSearchIter returns an iterator over all results of
+// harbormaster.target.search, following the result cursor starting at
+// req.Cursor. If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterBuildTargetSearchIter(
+	ctx context.Context,
+	req requests.HarbormasterBuildTargetSearchRequest,
+	maxItems int,
+) *HarbormasterBuildTargetSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.HarbormasterBuildTargetSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &HarbormasterBuildTargetSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// HarbormasterBuildTargetSearchAll calls harbormaster.target.search as many
+// times as needed to collect all results, following the result cursor. If
+// maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterBuildTargetSearchAll(
+	ctx context.Context,
+	req requests.HarbormasterBuildTargetSearchRequest,
+	maxItems int,
+) ([]*responses.HarbormasterBuildTargetSearchResponseItem, error) {
+	var items []*responses.HarbormasterBuildTargetSearchResponseItem
+	it := c.HarbormasterBuildTargetSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// HarbormasterBuildPlanSearchIterator iterates over all results of
+// harbormaster.buildplan.search.
+type HarbormasterBuildPlanSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *HarbormasterBuildPlanSearchIterator) Item() *responses.HarbormasterBuildPlanSearchResponseItem {
+	item, _ := it.item.(*responses.HarbormasterBuildPlanSearchResponseItem)
+	return item
+}
+
+// HarbormasterBuildPlanSearchIter returns an iterator over all results of
+// harbormaster.buildplan.search, following the result cursor starting at
+// req.Cursor. If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterBuildPlanSearchIter(
+	ctx context.Context,
+	req requests.HarbormasterBuildPlanSearchRequest,
+	maxItems int,
+) *HarbormasterBuildPlanSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.HarbormasterBuildPlanSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &HarbormasterBuildPlanSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// HarbormasterBuildPlanSearchAll calls harbormaster.buildplan.search as many
+// times as needed to collect all results, following the result cursor. If
+// maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterBuildPlanSearchAll(
+	ctx context.Context,
+	req requests.HarbormasterBuildPlanSearchRequest,
+	maxItems int,
+) ([]*responses.HarbormasterBuildPlanSearchResponseItem, error) {
+	var items []*responses.HarbormasterBuildPlanSearchResponseItem
+	it := c.HarbormasterBuildPlanSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// HarbormasterArtifactSearchIterator iterates over all results of
+// harbormaster.artifact.search.
+type HarbormasterArtifactSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *HarbormasterArtifactSearchIterator) Item() *responses.HarbormasterArtifactSearchResponseItem {
+	item, _ := it.item.(*responses.HarbormasterArtifactSearchResponseItem)
+	return item
+}
+
+// HarbormasterArtifactSearchIter returns an iterator over all results of
+// harbormaster.artifact.search, following the result cursor starting at
+// req.Cursor. If maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterArtifactSearchIter(
+	ctx context.Context,
+	req requests.HarbormasterArtifactSearchRequest,
+	maxItems int,
+) *HarbormasterArtifactSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.HarbormasterArtifactSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &HarbormasterArtifactSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// HarbormasterArtifactSearchAll calls harbormaster.artifact.search as many
+// times as needed to collect all results, following the result cursor. If
+// maxItems is positive, at most maxItems results are returned.
+func (c *Conn) HarbormasterArtifactSearchAll(
+	ctx context.Context,
+	req requests.HarbormasterArtifactSearchRequest,
+	maxItems int,
+) ([]*responses.HarbormasterArtifactSearchResponseItem, error) {
+	var items []*responses.HarbormasterArtifactSearchResponseItem
+	it := c.HarbormasterArtifactSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,11 +1,15 @@
 package gonduit
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/constants"
 	"github.com/uber/gonduit/core"
+	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 	"github.com/uber/gonduit/test/server"
@@ -94,3 +98,765 @@ func TestHarbormasterBuildableSearch(t *testing.T) {
 	}
 	assert.Equal(t, &want, resp)
 }
+
+func TestHarbormasterSendMessage(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(HarbormasterSendMessageMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{"result": null}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	err = c.HarbormasterSendMessage(requests.HarbormasterSendMessageRequest{
+		Receiver: "PHID-HMBT-1",
+		Type:     constants.HarbormasterMessageTypeFail,
+		Unit: []*requests.HarbormasterUnitResult{
+			{
+				Name:     "TestParse",
+				Result:   constants.HarbormasterUnitResultFail,
+				Duration: 0.25,
+				Path:     "parse_test.go",
+				Coverage: map[string]string{"parse.go": "NCCU"},
+				Details:  "expected 1, got 2",
+			},
+		},
+		Lint: []*requests.HarbormasterLintMessage{
+			{
+				Name:        "golint",
+				Code:        "GO1",
+				Severity:    constants.HarbormasterLintSeverityWarning,
+				Path:        "parse.go",
+				Line:        3,
+				Char:        7,
+				Description: "exported function should have comment",
+			},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "PHID-HMBT-1", params["receiver"])
+	assert.Equal(t, "fail", params["type"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":     "TestParse",
+			"result":   "fail",
+			"duration": 0.25,
+			"path":     "parse_test.go",
+			"coverage": map[string]interface{}{"parse.go": "NCCU"},
+			"details":  "expected 1, got 2",
+		},
+	}, params["unit"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{
+			"name":        "golint",
+			"code":        "GO1",
+			"severity":    "warning",
+			"path":        "parse.go",
+			"line":        float64(3),
+			"char":        float64(7),
+			"description": "exported function should have comment",
+		},
+	}, params["lint"])
+}
+
+func TestHarbormasterSendMessage_withInvalidMessage(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	called := false
+	s.RegisterMethodFunc(HarbormasterSendMessageMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		called = true
+		return http.StatusOK, server.ResponseFromJSON(`{"result": null}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	tests := map[string]requests.HarbormasterSendMessageRequest{
+		"no receiver": {
+			Type: constants.HarbormasterMessageTypePass,
+		},
+		"task receiver": {
+			Receiver: "PHID-TASK-1",
+			Type:     constants.HarbormasterMessageTypePass,
+		},
+		"monogram receiver": {
+			Receiver: "D123",
+			Type:     constants.HarbormasterMessageTypePass,
+		},
+		"receiver without id": {
+			Receiver: "PHID-HMBT-",
+			Type:     constants.HarbormasterMessageTypePass,
+		},
+		"unknown type": {
+			Receiver: "PHID-HMBT-1",
+			Type:     "done",
+		},
+		"unit without result": {
+			Receiver: "PHID-HMBT-1",
+			Type:     constants.HarbormasterMessageTypeWork,
+			Unit:     []*requests.HarbormasterUnitResult{{Name: "TestParse"}},
+		},
+		"lint without path": {
+			Receiver: "PHID-HMBB-1",
+			Type:     constants.HarbormasterMessageTypeWork,
+			Lint: []*requests.HarbormasterLintMessage{
+				{
+					Name:     "golint",
+					Code:     "GO1",
+					Severity: constants.HarbormasterLintSeverityError,
+				},
+			},
+		},
+	}
+
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := c.HarbormasterSendMessage(req)
+			assert.True(
+				t,
+				errors.Is(err, requests.ErrInvalidHarbormasterMessage),
+				"unexpected error: %v",
+				err,
+			)
+		})
+	}
+
+	assert.False(t, called)
+}
+
+func TestHarbormasterSendMessageRequest_Validate(t *testing.T) {
+	receivers := []string{
+		"PHID-HMBT-1",
+		"PHID-HMBB-1",
+		"PHID-DIFF-1",
+		"PHID-DREV-1",
+		"PHID-CMIT-1",
+	}
+
+	for _, receiver := range receivers {
+		req := requests.HarbormasterSendMessageRequest{
+			Receiver: receiver,
+			Type:     constants.HarbormasterMessageTypePass,
+		}
+
+		assert.Nil(t, req.Validate(), receiver)
+	}
+}
+
+const buildSearchResponseJSON = `{
+  "result": {
+    "data": [
+      {
+        "id": 1001,
+        "type": "HMBD",
+        "phid": "PHID-HMBD-1001",
+        "fields": {
+          "buildablePHID": "PHID-HMBB-6tceawkrkt55btokp7es",
+          "buildPlanPHID": "PHID-HMCP-1",
+          "buildStatus": {
+            "value": "failed",
+            "name": "Failed",
+            "color.ansi": "red"
+          },
+          "initiatorPHID": "PHID-USER-1",
+          "name": "Unit tests",
+          "dateCreated": 1419993553,
+          "dateModified": 1419994281,
+          "policy": {
+            "view": "users",
+            "edit": "users"
+          }
+        },
+        "attachments": {}
+      }
+    ],
+    "maps": {},
+    "query": {
+      "queryKey": null
+    },
+    "cursor": {
+      "limit": 100,
+      "after": null,
+      "before": null,
+      "order": null
+    }
+  }
+}`
+
+func TestHarbormasterBuildSearch(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(HarbormasterBuildSearchMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(buildSearchResponseJSON)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+	req := requests.HarbormasterBuildSearchRequest{
+		Constraints: &requests.HarbormasterBuildSearchConstraints{
+			Buildables: []string{"PHID-HMBB-6tceawkrkt55btokp7es"},
+			Statuses:   []entities.BuildStatus{entities.BuildStatusFailed},
+		},
+	}
+	resp, err := c.HarbormasterBuildSearch(req)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"buildables": []interface{}{"PHID-HMBB-6tceawkrkt55btokp7es"},
+		"statuses":   []interface{}{"failed"},
+	}, params["constraints"])
+
+	want := responses.HarbormasterBuildSearchResponse{
+		Data: []*responses.HarbormasterBuildSearchResponseItem{
+			{
+				ResponseObject: responses.ResponseObject{
+					ID:   1001,
+					Type: "HMBD",
+					PHID: "PHID-HMBD-1001",
+				},
+				Fields: responses.HarbormasterBuildSearchResponseItemFields{
+					BuildablePHID: "PHID-HMBB-6tceawkrkt55btokp7es",
+					BuildPlanPHID: "PHID-HMCP-1",
+					BuildStatus: responses.BuildStatus{
+						Value: entities.BuildStatusFailed,
+						Name:  "Failed",
+					},
+					InitiatorPHID: "PHID-USER-1",
+					Name:          "Unit tests",
+					DateCreated:   timestamp(1419993553),
+					DateModified:  timestamp(1419994281),
+				},
+			},
+		},
+		Cursor: responses.SearchCursor{
+			Limit: 100,
+		},
+	}
+	assert.Equal(t, &want, resp)
+}
+
+func TestHarbormasterBuildSearchAll(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	response := server.ResponseFromJSON(buildSearchResponseJSON)
+	s.RegisterMethod(HarbormasterBuildSearchMethod, http.StatusOK, response)
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	builds, err := c.HarbormasterBuildSearchAll(
+		context.Background(),
+		requests.HarbormasterBuildSearchRequest{},
+		0,
+	)
+	assert.NoError(t, err)
+	if assert.Len(t, builds, 1) {
+		assert.Equal(t, "PHID-HMBD-1001", builds[0].PHID)
+	}
+}
+
+func TestHarbormasterBuildTargetSearch(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(HarbormasterBuildTargetSearchMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": {
+				"data": [
+					{
+						"id": 7,
+						"type": "HMBT",
+						"phid": "PHID-HMBT-7",
+						"fields": {
+							"name": "Run tests",
+							"buildPHID": "PHID-HMBD-1001",
+							"buildStepPHID": "PHID-HMCS-1",
+							"status": {"value": "target/failed", "name": "Failed"},
+							"epochStarted": 1419993560,
+							"epochCompleted": 1419994200,
+							"buildGeneration": 2,
+							"dateCreated": 1419993553,
+							"dateModified": 1419994281
+						}
+					},
+					{
+						"id": 8,
+						"type": "HMBT",
+						"phid": "PHID-HMBT-8",
+						"fields": {
+							"name": "Deploy",
+							"buildPHID": "PHID-HMBD-1001",
+							"buildStepPHID": "PHID-HMCS-2",
+							"status": {"value": "target/pending", "name": "Pending"},
+							"epochStarted": null,
+							"epochCompleted": null,
+							"buildGeneration": 2,
+							"dateCreated": 1419993553,
+							"dateModified": 1419993553
+						}
+					}
+				],
+				"cursor": {"limit": 100, "after": null, "before": null}
+			}
+		}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	resp, err := c.HarbormasterBuildTargetSearch(
+		requests.HarbormasterBuildTargetSearchRequest{
+			Constraints: &requests.HarbormasterBuildTargetSearchConstraints{
+				BuildPHIDs: []string{"PHID-HMBD-1001"},
+			},
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"buildPHIDs": []interface{}{"PHID-HMBD-1001"},
+	}, params["constraints"])
+
+	started := timestamp(1419993560)
+	completed := timestamp(1419994200)
+	if assert.Len(t, resp.Data, 2) {
+		assert.Equal(t, responses.HarbormasterBuildTargetSearchResponseItemFields{
+			Name:          "Run tests",
+			BuildPHID:     "PHID-HMBD-1001",
+			BuildStepPHID: "PHID-HMCS-1",
+			Status: responses.BuildTargetStatus{
+				Value: entities.BuildTargetStatusFailed,
+				Name:  "Failed",
+			},
+			EpochStarted:    &started,
+			EpochCompleted:  &completed,
+			BuildGeneration: 2,
+			DateCreated:     timestamp(1419993553),
+			DateModified:    timestamp(1419994281),
+		}, resp.Data[0].Fields)
+
+		assert.Equal(t, entities.BuildTargetStatusPending, resp.Data[1].Fields.Status.Value)
+		assert.Nil(t, resp.Data[1].Fields.EpochStarted)
+		assert.Nil(t, resp.Data[1].Fields.EpochCompleted)
+	}
+}
+
+func TestHarbormasterBuildPlanSearch(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod(HarbormasterBuildPlanSearchMethod, http.StatusOK, server.ResponseFromJSON(`{
+		"result": {
+			"data": [
+				{
+					"id": 1,
+					"type": "HMCP",
+					"phid": "PHID-HMCP-1",
+					"fields": {
+						"name": "Unit tests",
+						"status": {"value": "active"},
+						"dateCreated": 1419993553,
+						"dateModified": 1419994281
+					}
+				}
+			],
+			"cursor": {"limit": 100, "after": null, "before": null}
+		}
+	}`))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	resp, err := c.HarbormasterBuildPlanSearch(
+		requests.HarbormasterBuildPlanSearchRequest{
+			Constraints: &requests.HarbormasterBuildPlanSearchConstraints{
+				Match: "tests",
+			},
+		},
+	)
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Data, 1) {
+		assert.Equal(t, responses.HarbormasterBuildPlanSearchResponseItemFields{
+			Name: "Unit tests",
+			Status: responses.BuildPlanStatus{
+				Value: entities.BuildPlanStatusActive,
+			},
+			DateCreated:  timestamp(1419993553),
+			DateModified: timestamp(1419994281),
+		}, resp.Data[0].Fields)
+	}
+}
+
+func TestHarbormasterArtifactSearch(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod(HarbormasterArtifactSearchMethod, http.StatusOK, server.ResponseFromJSON(`{
+		"result": {
+			"data": [
+				{
+					"id": 3,
+					"type": "HMBA",
+					"phid": "PHID-HMBA-3",
+					"fields": {
+						"buildTargetPHID": "PHID-HMBT-7",
+						"artifactType": "uri",
+						"artifactKey": "test-report",
+						"isReleased": false,
+						"dateCreated": 1419993553,
+						"dateModified": 1419994281
+					}
+				}
+			],
+			"cursor": {"limit": 100, "after": null, "before": null}
+		}
+	}`))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	resp, err := c.HarbormasterArtifactSearch(
+		requests.HarbormasterArtifactSearchRequest{
+			Constraints: &requests.HarbormasterArtifactSearchConstraints{
+				BuildTargetPHIDs: []string{"PHID-HMBT-7"},
+			},
+		},
+	)
+	assert.NoError(t, err)
+	if assert.Len(t, resp.Data, 1) {
+		assert.Equal(t, responses.HarbormasterArtifactSearchResponseItemFields{
+			BuildTargetPHID: "PHID-HMBT-7",
+			ArtifactType:    entities.ArtifactTypeURI,
+			ArtifactKey:     "test-report",
+			DateCreated:     timestamp(1419993553),
+			DateModified:    timestamp(1419994281),
+		}, resp.Data[0].Fields)
+	}
+}
+
+func TestHarbormasterCreateArtifact(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(HarbormasterCreateArtifactMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{
+			"result": {"data": [{"phid": "PHID-HMBA-4"}]}
+		}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	resp, err := c.HarbormasterCreateArtifact(
+		requests.HarbormasterCreateArtifactRequest{
+			BuildTargetPHID: "PHID-HMBT-7",
+			ArtifactKey:     "test-report",
+			ArtifactType:    entities.ArtifactTypeURI,
+			ArtifactData: map[string]interface{}{
+				"uri":         "https://ci.example.com/report/7",
+				"name":        "Test report",
+				"ui.external": true,
+			},
+		},
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, &responses.HarbormasterCreateArtifactResponse{
+		Data: []responses.HarbormasterCreateArtifactResponseItem{
+			{PHID: "PHID-HMBA-4"},
+		},
+	}, resp)
+	assert.Equal(t, "PHID-HMBT-7", params["buildTargetPHID"])
+	assert.Equal(t, "uri", params["artifactType"])
+	assert.Equal(t, map[string]interface{}{
+		"uri":         "https://ci.example.com/report/7",
+		"name":        "Test report",
+		"ui.external": true,
+	}, params["artifactData"])
+}
+
+func TestHarbormasterBuildEdit(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(HarbormasterBuildEditMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(editResponseJSON)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	req := requests.HarbormasterBuildEditRequest{
+		ObjectIdentifier: "PHID-HMBD-1001",
+	}
+	req.Restart()
+
+	_, err = c.HarbormasterBuildEdit(req)
+	assert.Nil(t, err)
+	assert.Equal(t, "PHID-HMBD-1001", params["objectIdentifier"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "restart", "value": true},
+	}, params["transactions"])
+}
+
+func TestHarbormasterBuildEditRequest(t *testing.T) {
+	req := requests.HarbormasterBuildEditRequest{}
+	req.Pause().Resume().Abort()
+
+	assert.Equal(t, []requests.Transaction{
+		{Type: "pause", Value: true},
+		{Type: "resume", Value: true},
+		{Type: "abort", Value: true},
+	}, req.Transactions)
+}
+
+func TestHarbormasterCommand(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(HarbormasterCommandMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(`{"result": null}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	err = c.HarbormasterCommand(requests.HarbormasterCommandRequest{
+		Receiver: "PHID-HMBD-1001",
+		Command:  constants.HarbormasterBuildCommandAbort,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "PHID-HMBD-1001", params["receiver"])
+	assert.Equal(t, "abort", params["command"])
+}
+
+const buildableBuildsResponseJSON = `{
+  "result": {
+    "data": [
+      {
+        "id": 1,
+        "type": "HMBD",
+        "phid": "PHID-HMBD-1",
+        "fields": {"buildStatus": {"value": "building"}}
+      },
+      {
+        "id": 2,
+        "type": "HMBD",
+        "phid": "PHID-HMBD-2",
+        "fields": {"buildStatus": {"value": "passed"}}
+      },
+      {
+        "id": 3,
+        "type": "HMBD",
+        "phid": "PHID-HMBD-3",
+        "fields": {"buildStatus": {"value": "paused"}}
+      }
+    ],
+    "cursor": {"limit": 100, "after": null, "before": null}
+  }
+}`
+
+func TestHarbormasterCommandBuildable(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var searchParams map[string]interface{}
+	s.RegisterMethodFunc(HarbormasterBuildSearchMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		searchParams = p
+		return http.StatusOK, server.ResponseFromJSON(buildableBuildsResponseJSON)
+	})
+
+	var edited []interface{}
+	s.RegisterMethodFunc(HarbormasterBuildEditMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		edited = append(edited, p["objectIdentifier"])
+		return http.StatusOK, server.ResponseFromJSON(editResponseJSON)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	commanded, err := c.HarbormasterCommandBuildableContext(
+		context.Background(),
+		"PHID-HMBB-1",
+		constants.HarbormasterBuildCommandAbort,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PHID-HMBD-1", "PHID-HMBD-3"}, commanded)
+	assert.Equal(t, []interface{}{"PHID-HMBD-1", "PHID-HMBD-3"}, edited)
+	assert.Equal(t, map[string]interface{}{
+		"buildables": []interface{}{"PHID-HMBB-1"},
+	}, searchParams["constraints"])
+
+	edited = nil
+	commanded, err = c.HarbormasterCommandBuildable(
+		"PHID-HMBB-1",
+		constants.HarbormasterBuildCommandRestart,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PHID-HMBD-2"}, commanded)
+	assert.Equal(t, []interface{}{"PHID-HMBD-2"}, edited)
+}
+
+func TestHarbormasterCommandBuildable_withError(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod(
+		HarbormasterBuildSearchMethod,
+		http.StatusOK,
+		server.ResponseFromJSON(buildableBuildsResponseJSON),
+	)
+
+	calls := 0
+	s.RegisterMethodFunc(HarbormasterBuildEditMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		calls++
+		if calls > 1 {
+			return http.StatusOK, server.ResponseFromJSON(`{
+				"error_code": "ERR-CONDUIT-CORE",
+				"error_info": "This build can not be paused."
+			}`)
+		}
+		return http.StatusOK, server.ResponseFromJSON(editResponseJSON)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	commanded, err := c.HarbormasterCommandBuildableContext(
+		context.Background(),
+		"PHID-HMBB-1",
+		constants.HarbormasterBuildCommandAbort,
+	)
+	assert.NotNil(t, err)
+	assert.Equal(t, []string{"PHID-HMBD-1"}, commanded)
+}
+
+func TestHarbormasterCommandBuildable_withoutBuildEdit(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod(
+		HarbormasterBuildSearchMethod,
+		http.StatusOK,
+		server.ResponseFromJSON(buildableBuildsResponseJSON),
+	)
+
+	var receivers []interface{}
+	s.RegisterMethodFunc(HarbormasterCommandMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		assert.Equal(t, "abort", p["command"])
+		receivers = append(receivers, p["receiver"])
+		return http.StatusOK, server.ResponseFromJSON(`{"result": null}`)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	commanded, err := c.HarbormasterCommandBuildableContext(
+		context.Background(),
+		"PHID-HMBB-1",
+		constants.HarbormasterBuildCommandAbort,
+	)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"PHID-HMBD-1", "PHID-HMBD-3"}, commanded)
+	assert.Equal(t, []interface{}{"PHID-HMBD-1", "PHID-HMBD-3"}, receivers)
+}
+
+func TestCanIssueBuildCommand(t *testing.T) {
+	tests := []struct {
+		status  entities.BuildStatus
+		command constants.HarbormasterBuildCommand
+		want    bool
+	}{
+		{entities.BuildStatusPassed, constants.HarbormasterBuildCommandRestart, true},
+		{entities.BuildStatusBuilding, constants.HarbormasterBuildCommandRestart, false},
+		{entities.BuildStatusBuilding, constants.HarbormasterBuildCommandPause, true},
+		{entities.BuildStatusPaused, constants.HarbormasterBuildCommandPause, false},
+		{entities.BuildStatusFailed, constants.HarbormasterBuildCommandPause, false},
+		{entities.BuildStatusPaused, constants.HarbormasterBuildCommandResume, true},
+		{entities.BuildStatusBuilding, constants.HarbormasterBuildCommandResume, false},
+		{entities.BuildStatusPending, constants.HarbormasterBuildCommandAbort, true},
+		{entities.BuildStatusAborted, constants.HarbormasterBuildCommandAbort, false},
+		{entities.BuildStatusPassed, "custom", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(
+			t,
+			tt.want,
+			canIssueBuildCommand(tt.status, tt.command),
+			"%s on %s build",
+			tt.command,
+			tt.status,
+		)
+	}
+}
@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -8,10 +10,20 @@ import (
 // MacroCreateMeme performs a call to macro.creatememe.
 func (c *Conn) MacroCreateMeme(
 	req requests.MacroCreateMemeRequest,
+) (*responses.MacroCreateMemeResponse, error) {
+	ctx := context.Background()
+	return c.MacroCreateMemeContext(ctx, req)
+}
+
+// MacroCreateMemeContext performs a call to macro.creatememe, passing through
+// the given context.
+func (c *Conn) MacroCreateMemeContext(
+	ctx context.Context,
+	req requests.MacroCreateMemeRequest,
 ) (*responses.MacroCreateMemeResponse, error) {
 	var res responses.MacroCreateMemeResponse
 
-	if err := c.Call("macro.creatememe", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "macro.creatememe", &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
@@ -9,10 +11,20 @@ import (
 // ManiphestQuery performs a call to maniphest.query.
 func (c *Conn) ManiphestQuery(
 	req requests.ManiphestQueryRequest,
+) (*responses.ManiphestQueryResponse, error) {
+	ctx := context.Background()
+	return c.ManiphestQueryContext(ctx, req)
+}
+
+// ManiphestQueryContext performs a call to maniphest.query, passing through the
+// given context.
+func (c *Conn) ManiphestQueryContext(
+	ctx context.Context,
+	req requests.ManiphestQueryRequest,
 ) (*responses.ManiphestQueryResponse, error) {
 	var res responses.ManiphestQueryResponse
 
-	if err := c.Call("maniphest.query", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "maniphest.query", &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -22,37 +34,160 @@ func (c *Conn) ManiphestQuery(
 // ManiphestCreateTask performs a call to maniphest.createtask.
 func (c *Conn) ManiphestCreateTask(
 	req requests.ManiphestCreateTaskRequest,
+) (*entities.ManiphestTask, error) {
+	ctx := context.Background()
+	return c.ManiphestCreateTaskContext(ctx, req)
+}
+
+// ManiphestCreateTaskContext performs a call to maniphest.createtask, passing
+// through the given context.
+func (c *Conn) ManiphestCreateTaskContext(
+	ctx context.Context,
+	req requests.ManiphestCreateTaskRequest,
 ) (*entities.ManiphestTask, error) {
 	var res entities.ManiphestTask
 
-	if err := c.Call("maniphest.createtask", &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, "maniphest.createtask", &req, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
 
+// ManiphestGetTaskTransactions performs a call to maniphest.gettasktransactions.
 func (c *Conn) ManiphestGetTaskTransactions(
 	req requests.ManiphestGetTaskTransactions,
+) (*responses.ManiphestGetTaskTransactionsResponse, error) {
+	ctx := context.Background()
+	return c.ManiphestGetTaskTransactionsContext(ctx, req)
+}
+
+// ManiphestGetTaskTransactionsContext performs a call to
+// maniphest.gettasktransactions, passing through the given context.
+func (c *Conn) ManiphestGetTaskTransactionsContext(
+	ctx context.Context,
+	req requests.ManiphestGetTaskTransactions,
 ) (*responses.ManiphestGetTaskTransactionsResponse, error) {
 	var res responses.ManiphestGetTaskTransactionsResponse
 
-	if err := c.Call("maniphest.gettasktransactions", &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, "maniphest.gettasktransactions", &req, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
 
+// ManiphestEditMethod is method name on Phabricator API.
+const ManiphestEditMethod = "maniphest.edit"
+
+// ManiphestEdit performs a call to maniphest.edit.
+func (c *Conn) ManiphestEdit(
+	req requests.ManiphestEditRequest,
+) (*responses.EditResponse, error) {
+	ctx := context.Background()
+	return c.ManiphestEditContext(ctx, req)
+}
+
+// ManiphestEditContext performs a call to maniphest.edit, passing through the
+// given context.
+func (c *Conn) ManiphestEditContext(
+	ctx context.Context,
+	req requests.ManiphestEditRequest,
+) (*responses.EditResponse, error) {
+	var res responses.EditResponse
+
+	if err := c.CallContext(
+		ctx, ManiphestEditMethod, &req, &res); err != nil {
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+// ManiphestSearchMethod is method name on Phabricator API.
+const ManiphestSearchMethod = "maniphest.search"
+
 // ManiphestSearch performs a call to maniphest.search.
 func (c *Conn) ManiphestSearch(
 	req requests.ManiphestSearchRequest,
+) (*responses.ManiphestSearchResponse, error) {
+	ctx := context.Background()
+	return c.ManiphestSearchContext(ctx, req)
+}
+
+// ManiphestSearchContext performs a call to maniphest.search, passing through
+// the given context.
+func (c *Conn) ManiphestSearchContext(
+	ctx context.Context,
+	req requests.ManiphestSearchRequest,
 ) (*responses.ManiphestSearchResponse, error) {
 	var res responses.ManiphestSearchResponse
 
-	if err := c.Call("maniphest.search", &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, ManiphestSearchMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// ManiphestSearchIterator iterates over all results of maniphest.search.
+type ManiphestSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *ManiphestSearchIterator) Item() *responses.ManiphestSearchResponseItem {
+	item, _ := it.item.(*responses.ManiphestSearchResponseItem)
+	return item
+}
+
+// ManiphestSearchIter returns an iterator over all results of maniphest.search,
+// following the result cursor starting at req.Cursor. If maxItems is positive,
+// at most maxItems results are returned.
+func (c *Conn) ManiphestSearchIter(
+	ctx context.Context,
+	req requests.ManiphestSearchRequest,
+	maxItems int,
+) *ManiphestSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.ManiphestSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &ManiphestSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// ManiphestSearchAll calls maniphest.search as many times as needed to collect
+// all results, following the result cursor. If maxItems is positive, at most
+// maxItems results are returned.
+func (c *Conn) ManiphestSearchAll(
+	ctx context.Context,
+	req requests.ManiphestSearchRequest,
+	maxItems int,
+) ([]*responses.ManiphestSearchResponseItem, error) {
+	var items []*responses.ManiphestSearchResponseItem
+	it := c.ManiphestSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,145 @@
+package gonduit
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/constants"
+	"github.com/uber/gonduit/core"
+	"github.com/uber/gonduit/requests"
+	"github.com/uber/gonduit/responses"
+	"github.com/uber/gonduit/test/server"
+)
+
+const editResponseJSON = `{
+  "result": {
+    "object": {
+      "id": 123,
+      "phid": "PHID-TASK-ijkmc2uqpjdwfpscjp25"
+    },
+    "transactions": [
+      {"phid": "PHID-XACT-TASK-uo2rrplljewshsf"},
+      {"phid": "PHID-XACT-TASK-h4mg7bwnufjmykq"}
+    ]
+  }
+}`
+
+func TestManiphestEdit(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(ManiphestEditMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(editResponseJSON)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	req := requests.ManiphestEditRequest{ObjectIdentifier: "T123"}
+	req.SetTitle("Fix the build").
+		SetDescription("It is broken.").
+		SetStatus(constants.ManiphestEditStatusResolved).
+		SetPriority(constants.ManiphestEditPriorityHigh).
+		SetOwner("").
+		AddProjects("PHID-PROJ-1").
+		RemoveProjects("PHID-PROJ-2").
+		AddSubscribers("PHID-USER-1", "PHID-USER-2").
+		RemoveSubscribers("PHID-USER-3").
+		SetParent("PHID-TASK-1").
+		AddSubtasks("PHID-TASK-2").
+		RemoveSubtasks("PHID-TASK-3").
+		MoveToColumns("PHID-PCOL-1").
+		AddComment("Fixed.").
+		SetCustomField("acme:severity", "low").
+		SetCustomField("custom.acme:team", "infra")
+
+	res, err := c.ManiphestEdit(req)
+	assert.Nil(t, err)
+
+	assert.Equal(t, &responses.EditResponse{
+		Object: responses.EditResponseObject{
+			ID:   123,
+			PHID: "PHID-TASK-ijkmc2uqpjdwfpscjp25",
+		},
+		Transactions: []responses.EditResponseTransaction{
+			{PHID: "PHID-XACT-TASK-uo2rrplljewshsf"},
+			{PHID: "PHID-XACT-TASK-h4mg7bwnufjmykq"},
+		},
+	}, res)
+
+	assert.Equal(t, "T123", params["objectIdentifier"])
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"type": "title", "value": "Fix the build"},
+		map[string]interface{}{"type": "description", "value": "It is broken."},
+		map[string]interface{}{"type": "status", "value": "resolved"},
+		map[string]interface{}{"type": "priority", "value": "high"},
+		map[string]interface{}{"type": "owner", "value": nil},
+		map[string]interface{}{
+			"type":  "projects.add",
+			"value": []interface{}{"PHID-PROJ-1"},
+		},
+		map[string]interface{}{
+			"type":  "projects.remove",
+			"value": []interface{}{"PHID-PROJ-2"},
+		},
+		map[string]interface{}{
+			"type":  "subscribers.add",
+			"value": []interface{}{"PHID-USER-1", "PHID-USER-2"},
+		},
+		map[string]interface{}{
+			"type":  "subscribers.remove",
+			"value": []interface{}{"PHID-USER-3"},
+		},
+		map[string]interface{}{"type": "parent", "value": "PHID-TASK-1"},
+		map[string]interface{}{
+			"type":  "subtasks.add",
+			"value": []interface{}{"PHID-TASK-2"},
+		},
+		map[string]interface{}{
+			"type":  "subtasks.remove",
+			"value": []interface{}{"PHID-TASK-3"},
+		},
+		map[string]interface{}{
+			"type":  "column",
+			"value": []interface{}{"PHID-PCOL-1"},
+		},
+		map[string]interface{}{"type": "comment", "value": "Fixed."},
+		map[string]interface{}{"type": "custom.acme:severity", "value": "low"},
+		map[string]interface{}{"type": "custom.acme:team", "value": "infra"},
+	}, params["transactions"])
+}
+
+func TestManiphestEdit_withNewTask(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+
+	var params map[string]interface{}
+	s.RegisterMethodFunc(ManiphestEditMethod, func(
+		p map[string]interface{},
+	) (int, map[string]interface{}) {
+		params = p
+		return http.StatusOK, server.ResponseFromJSON(editResponseJSON)
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	req := requests.ManiphestEditRequest{}
+	req.SetTitle("New task")
+
+	res, err := c.ManiphestEdit(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 123, res.Object.ID)
+	assert.NotContains(t, params, "objectIdentifier")
+}
@@ -0,0 +1,142 @@
+package gonduit
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/uber/gonduit/entities"
+)
+
+// maxSearchPageLimit is the largest page size *.search methods accept.
+const maxSearchPageLimit = 100
+
+// searchPageFunc fetches the page of search results located by the given
+// cursor. It returns the items on the page and the "after" cursor of the next
+// page, which is zero when there are no more pages.
+type searchPageFunc func(
+	ctx context.Context,
+	cursor *entities.Cursor,
+) ([]interface{}, uint64, error)
+
+// SearchIterator pages through the results of a *.search method, following
+// the result cursor until the results are exhausted, the context is cancelled
+// or the maximum number of items is reached. It only pages forward, following
+// the "after" cursor; starting from a cursor with Before set is rejected with
+// ErrSearchBeforeCursor.
+//
+// SearchIterator is not used on its own: every search method has a typed
+// iterator embedding it (e.g. ManiphestSearchIterator) which adds an Item
+// method returning the current result.
+type SearchIterator struct {
+	ctx      context.Context
+	fetch    searchPageFunc
+	cursor   entities.Cursor
+	maxItems int
+	count    int
+	page     []interface{}
+	item     interface{}
+	done     bool
+	err      error
+}
+
+// ErrSearchBeforeCursor is returned by search iterators started from a cursor
+// with Before set, as they can only page forward.
+var ErrSearchBeforeCursor = errors.New(
+	"search iterators can not page backwards from a Before cursor",
+)
+
+// newSearchIterator creates an iterator starting at the given cursor, which
+// may be nil. If maxItems is positive, at most maxItems results are returned.
+func newSearchIterator(
+	ctx context.Context,
+	cursor *entities.Cursor,
+	maxItems int,
+	fetch searchPageFunc,
+) *SearchIterator {
+	it := &SearchIterator{
+		ctx:      ctx,
+		fetch:    fetch,
+		maxItems: maxItems,
+	}
+	if cursor != nil {
+		it.cursor = *cursor
+	}
+	if it.cursor.Before != 0 {
+		it.err = ErrSearchBeforeCursor
+	}
+	return it
+}
+
+// Next advances the iterator to the next result, fetching the next page from
+// the server when the current one is consumed. It returns false when there are
+// no more results or an error occurred, in which case Err returns the error.
+func (it *SearchIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	if it.maxItems > 0 && it.count >= it.maxItems {
+		return false
+	}
+	if err := it.ctx.Err(); err != nil {
+		it.err = err
+		return false
+	}
+
+	for len(it.page) == 0 {
+		if it.done {
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			return false
+		}
+	}
+
+	it.item, it.page = it.page[0], it.page[1:]
+	it.count++
+
+	return true
+}
+
+// Err returns the error which stopped the iteration, if any.
+func (it *SearchIterator) Err() error {
+	return it.err
+}
+
+func (it *SearchIterator) fetchPage() error {
+	cursor := it.cursor
+	if it.maxItems > 0 {
+		// Do not ask the server for more results than we are going to return.
+		remaining := uint64(it.maxItems - it.count)
+		if cursor.Limit == 0 || cursor.Limit > remaining {
+			cursor.Limit = remaining
+		}
+	}
+	if cursor.Limit > maxSearchPageLimit {
+		cursor.Limit = maxSearchPageLimit
+	}
+
+	items, after, err := it.fetch(it.ctx, &cursor)
+	if err != nil {
+		return err
+	}
+
+	it.page = items
+	if after == 0 || after == it.cursor.After {
+		it.done = true
+		return nil
+	}
+	it.cursor.After = after
+
+	return nil
+}
+
+// parseSearchCursorAfter converts the "after" value of a responses.SearchCursor
+// into the value expected by entities.Cursor in a request.
+func parseSearchCursorAfter(after string) (uint64, error) {
+	if after == "" {
+		return 0, nil
+	}
+	return strconv.ParseUint(after, 10, 64)
+}
@@ -0,0 +1,290 @@
+package gonduit
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/core"
+	"github.com/uber/gonduit/entities"
+	"github.com/uber/gonduit/requests"
+	"github.com/uber/gonduit/test/server"
+)
+
+// pagedSearchResponse serves items 1..total in pages of pageSize following
+// the "after" cursor sent in params. Like conduit, it rejects limits above
+// 100.
+func pagedSearchResponse(total, pageSize int, calls *int) server.MethodFunc {
+	return func(params map[string]interface{}) (int, map[string]interface{}) {
+		*calls++
+		start := 0
+		if after, ok := params["after"].(float64); ok {
+			start = int(after)
+		}
+		limit := pageSize
+		if l, ok := params["limit"].(float64); ok {
+			if l > 100 {
+				return http.StatusOK, server.ResponseFromJSON(`{
+					"error_code": "ERR-CONDUIT-CORE",
+					"error_info": "Maximum page size for Conduit API method calls is 100, but this call specified 101 or more."
+				}`)
+			}
+			if int(l) < limit {
+				limit = int(l)
+			}
+		}
+
+		data := []interface{}{}
+		for id := start + 1; id <= total && id <= start+limit; id++ {
+			data = append(data, map[string]interface{}{
+				"id":   id,
+				"type": "TASK",
+				"phid": fmt.Sprintf("PHID-TASK-%d", id),
+			})
+		}
+
+		var after interface{}
+		if start+limit < total {
+			after = fmt.Sprintf("%d", start+limit)
+		}
+
+		return http.StatusOK, map[string]interface{}{
+			"result": map[string]interface{}{
+				"data": data,
+				"cursor": map[string]interface{}{
+					"limit": limit,
+					"after": after,
+				},
+			},
+		}
+	}
+}
+
+func TestManiphestSearchAll(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	calls := 0
+	s.RegisterMethodFunc(ManiphestSearchMethod, pagedSearchResponse(7, 3, &calls))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	items, err := c.ManiphestSearchAll(
+		context.Background(),
+		requests.ManiphestSearchRequest{},
+		0,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	if assert.Len(t, items, 7) {
+		for i, item := range items {
+			assert.Equal(t, i+1, item.ID)
+		}
+	}
+}
+
+func TestManiphestSearchAll_withMaxItems(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	calls := 0
+	s.RegisterMethodFunc(ManiphestSearchMethod, pagedSearchResponse(7, 3, &calls))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	items, err := c.ManiphestSearchAll(
+		context.Background(),
+		requests.ManiphestSearchRequest{},
+		4,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, calls)
+	if assert.Len(t, items, 4) {
+		assert.Equal(t, 4, items[3].ID)
+	}
+}
+
+func TestManiphestSearchAll_withMaxItemsAbovePageLimit(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	calls := 0
+	s.RegisterMethodFunc(ManiphestSearchMethod, pagedSearchResponse(300, 100, &calls))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	items, err := c.ManiphestSearchAll(
+		context.Background(),
+		requests.ManiphestSearchRequest{},
+		250,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, calls)
+	if assert.Len(t, items, 250) {
+		assert.Equal(t, 250, items[249].ID)
+	}
+}
+
+func TestManiphestSearchIter_withCursor(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	calls := 0
+	s.RegisterMethodFunc(ManiphestSearchMethod, pagedSearchResponse(7, 3, &calls))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	it := c.ManiphestSearchIter(
+		context.Background(),
+		requests.ManiphestSearchRequest{
+			Cursor: &entities.Cursor{Limit: 2, After: 3},
+		},
+		0,
+	)
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Item().ID)
+	}
+	assert.NoError(t, it.Err())
+	assert.Equal(t, []int{4, 5, 6, 7}, ids)
+	assert.Equal(t, 2, calls)
+}
+
+func TestManiphestSearchIter_withBeforeCursor(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	calls := 0
+	s.RegisterMethodFunc(ManiphestSearchMethod, pagedSearchResponse(7, 3, &calls))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	it := c.ManiphestSearchIter(
+		context.Background(),
+		requests.ManiphestSearchRequest{
+			Cursor: &entities.Cursor{Before: 5},
+		},
+		0,
+	)
+	assert.False(t, it.Next())
+	assert.Equal(t, ErrSearchBeforeCursor, it.Err())
+	assert.Equal(t, 0, calls)
+}
+
+func TestManiphestSearchIter_withCancelledContext(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	calls := 0
+	s.RegisterMethodFunc(ManiphestSearchMethod, pagedSearchResponse(7, 3, &calls))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	it := c.ManiphestSearchIter(ctx, requests.ManiphestSearchRequest{}, 0)
+	assert.True(t, it.Next())
+	cancel()
+	assert.False(t, it.Next())
+	assert.Equal(t, context.Canceled, it.Err())
+	assert.Equal(t, 1, calls)
+}
+
+func TestManiphestSearchIter_withError(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethod(ManiphestSearchMethod, http.StatusOK, server.ResponseFromJSON(`{
+		"result": null,
+		"error_code": "ERR-CONDUIT-CORE",
+		"error_info": "Something bad happened"
+	}`))
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	it := c.ManiphestSearchIter(
+		context.Background(),
+		requests.ManiphestSearchRequest{},
+		0,
+	)
+	assert.False(t, it.Next())
+	assert.True(t, core.IsConduitError(it.Err()))
+	assert.Nil(t, it.Item())
+}
+
+func TestEdgeSearchAll(t *testing.T) {
+	s := server.New()
+	defer s.Close()
+	s.RegisterCapabilities()
+	s.RegisterMethodFunc(EdgeSearchMethod, func(
+		params map[string]interface{},
+	) (int, map[string]interface{}) {
+		destination, after := "PHID-TASK-100", interface{}(1)
+		if params["after"] != nil {
+			destination, after = "PHID-DREV-200", nil
+		}
+		return http.StatusOK, map[string]interface{}{
+			"result": map[string]interface{}{
+				"data": []interface{}{
+					map[string]interface{}{
+						"sourcePHID":      "PHID-TASK-123",
+						"edgeType":        "mention",
+						"destinationPHID": destination,
+					},
+				},
+				"cursor": map[string]interface{}{
+					"limit": 1,
+					"after": after,
+				},
+			},
+		}
+	})
+
+	c, err := Dial(s.GetURL(), &core.ClientOptions{
+		APIToken: "some-token",
+	})
+	assert.Nil(t, err)
+
+	edges, err := c.EdgeSearchAll(
+		context.Background(),
+		requests.EdgeSearchRequest{
+			SourcePHIDs: []string{"PHID-TASK-123"},
+		},
+		0,
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []entities.Edge{
+		{
+			SourcePHID:      "PHID-TASK-123",
+			DestinationPHID: "PHID-TASK-100",
+			EdgeType:        entities.EdgeMention,
+		},
+		{
+			SourcePHID:      "PHID-TASK-123",
+			DestinationPHID: "PHID-DREV-200",
+			EdgeType:        entities.EdgeMention,
+		},
+	}, edges)
+}
@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -8,10 +10,20 @@ import (
 // PasteCreate calls the paste.create endpoint.
 func (c *Conn) PasteCreate(
 	req *requests.PasteCreateRequest,
+) (responses.PasteCreateResponse, error) {
+	ctx := context.Background()
+	return c.PasteCreateContext(ctx, req)
+}
+
+// PasteCreateContext calls the paste.create endpoint, passing through the given
+// context.
+func (c *Conn) PasteCreateContext(
+	ctx context.Context,
+	req *requests.PasteCreateRequest,
 ) (responses.PasteCreateResponse, error) {
 	var res responses.PasteCreateResponse
 
-	if err := c.Call("paste.create", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "paste.create", &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -21,10 +33,20 @@ func (c *Conn) PasteCreate(
 // PasteQuery calls the paste.query endpoint.
 func (c *Conn) PasteQuery(
 	req *requests.PasteQueryRequest,
+) (responses.PasteQueryResponse, error) {
+	ctx := context.Background()
+	return c.PasteQueryContext(ctx, req)
+}
+
+// PasteQueryContext calls the paste.query endpoint, passing through the given
+// context.
+func (c *Conn) PasteQueryContext(
+	ctx context.Context,
+	req *requests.PasteQueryRequest,
 ) (responses.PasteQueryResponse, error) {
 	var res responses.PasteQueryResponse
 
-	if err := c.Call("paste.query", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "paste.query", &req, &res); err != nil {
 		return nil, err
 	}
 
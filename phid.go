@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
@@ -9,10 +11,20 @@ import (
 // PHIDLookup calls the phid.lookup endpoint.
 func (c *Conn) PHIDLookup(
 	req requests.PHIDLookupRequest,
+) (responses.PHIDLookupResponse, error) {
+	ctx := context.Background()
+	return c.PHIDLookupContext(ctx, req)
+}
+
+// PHIDLookupContext calls the phid.lookup endpoint, passing through the given
+// context.
+func (c *Conn) PHIDLookupContext(
+	ctx context.Context,
+	req requests.PHIDLookupRequest,
 ) (responses.PHIDLookupResponse, error) {
 	var r responses.PHIDLookupResponse
 
-	if err := c.Call("phid.lookup", &req, &r); err != nil {
+	if err := c.CallContext(ctx, "phid.lookup", &req, &r); err != nil {
 		return nil, err
 	}
 
@@ -21,11 +33,21 @@ func (c *Conn) PHIDLookup(
 
 // PHIDLookupSingle calls the phid.lookup endpoint with a single name.
 func (c *Conn) PHIDLookupSingle(name string) (*entities.PHIDResult, error) {
+	ctx := context.Background()
+	return c.PHIDLookupSingleContext(ctx, name)
+}
+
+// PHIDLookupSingleContext calls the phid.lookup endpoint with a single name,
+// passing through the given context.
+func (c *Conn) PHIDLookupSingleContext(
+	ctx context.Context,
+	name string,
+) (*entities.PHIDResult, error) {
 	req := requests.PHIDLookupRequest{
 		Names: []string{name},
 	}
 
-	resp, err := c.PHIDLookup(req)
+	resp, err := c.PHIDLookupContext(ctx, req)
 
 	if err != nil {
 		return nil, err
@@ -37,10 +59,20 @@ func (c *Conn) PHIDLookupSingle(name string) (*entities.PHIDResult, error) {
 // PHIDQuery calls the phid.query endpoint.
 func (c *Conn) PHIDQuery(
 	req requests.PHIDQueryRequest,
+) (responses.PHIDQueryResponse, error) {
+	ctx := context.Background()
+	return c.PHIDQueryContext(ctx, req)
+}
+
+// PHIDQueryContext calls the phid.query endpoint, passing through the given
+// context.
+func (c *Conn) PHIDQueryContext(
+	ctx context.Context,
+	req requests.PHIDQueryRequest,
 ) (responses.PHIDQueryResponse, error) {
 	var r responses.PHIDQueryResponse
 
-	if err := c.Call("phid.query", &req, &r); err != nil {
+	if err := c.CallContext(ctx, "phid.query", &req, &r); err != nil {
 		return nil, err
 	}
 
@@ -49,7 +81,17 @@ func (c *Conn) PHIDQuery(
 
 // PHIDQuerySingle calls the phid.query endpoint with a single phid.
 func (c *Conn) PHIDQuerySingle(phid string) (*entities.PHIDResult, error) {
-	resp, err := c.PHIDQuery(requests.PHIDQueryRequest{
+	ctx := context.Background()
+	return c.PHIDQuerySingleContext(ctx, phid)
+}
+
+// PHIDQuerySingleContext calls the phid.query endpoint with a single phid,
+// passing through the given context.
+func (c *Conn) PHIDQuerySingleContext(
+	ctx context.Context,
+	phid string,
+) (*entities.PHIDResult, error) {
+	resp, err := c.PHIDQueryContext(ctx, requests.PHIDQueryRequest{
 		PHIDs: []string{phid},
 	})
 
@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -8,10 +10,20 @@ import (
 // PhrictionInfo performs a call to phriction.info
 func (c *Conn) PhrictionInfo(
 	req requests.PhrictionInfoRequest,
+) (*responses.PhrictionInfoResponse, error) {
+	ctx := context.Background()
+	return c.PhrictionInfoContext(ctx, req)
+}
+
+// PhrictionInfoContext performs a call to phriction.info, passing through the
+// given context.
+func (c *Conn) PhrictionInfoContext(
+	ctx context.Context,
+	req requests.PhrictionInfoRequest,
 ) (*responses.PhrictionInfoResponse, error) {
 	var res responses.PhrictionInfoResponse
 
-	if err := c.Call("phriction.info", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "phriction.info", &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,9 @@
 package gonduit
 
 import (
+	"context"
+
+	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -11,10 +14,20 @@ const ProjectQueryMethod = "project.query"
 // ProjectQuery performs a call to project.query.
 func (c *Conn) ProjectQuery(
 	req requests.ProjectQueryRequest,
+) (*responses.ProjectQueryResponse, error) {
+	ctx := context.Background()
+	return c.ProjectQueryContext(ctx, req)
+}
+
+// ProjectQueryContext performs a call to project.query, passing through the
+// given context.
+func (c *Conn) ProjectQueryContext(
+	ctx context.Context,
+	req requests.ProjectQueryRequest,
 ) (*responses.ProjectQueryResponse, error) {
 	var res responses.ProjectQueryResponse
 
-	if err := c.Call(ProjectQueryMethod, &req, &res); err != nil {
+	if err := c.CallContext(ctx, ProjectQueryMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -27,12 +40,81 @@ const ProjectSearchMethod = "project.search"
 // ProjectSearch performs a call to project.search.
 func (c *Conn) ProjectSearch(
 	req requests.ProjectSearchRequest,
+) (*responses.ProjectSearchResponse, error) {
+	ctx := context.Background()
+	return c.ProjectSearchContext(ctx, req)
+}
+
+// ProjectSearchContext performs a call to project.search, passing through the
+// given context.
+func (c *Conn) ProjectSearchContext(
+	ctx context.Context,
+	req requests.ProjectSearchRequest,
 ) (*responses.ProjectSearchResponse, error) {
 	var res responses.ProjectSearchResponse
 
-	if err := c.Call(ProjectSearchMethod, &req, &res); err != nil {
+	if err := c.CallContext(ctx, ProjectSearchMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// ProjectSearchIterator iterates over all results of project.search.
+type ProjectSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *ProjectSearchIterator) Item() *responses.ProjectSearchResponseItem {
+	item, _ := it.item.(*responses.ProjectSearchResponseItem)
+	return item
+}
+
+// ProjectSearchIter returns an iterator over all results of project.search,
+// following the result cursor starting at req.Cursor. If maxItems is positive,
+// at most maxItems results are returned.
+func (c *Conn) ProjectSearchIter(
+	ctx context.Context,
+	req requests.ProjectSearchRequest,
+	maxItems int,
+) *ProjectSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.ProjectSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		after, err := parseSearchCursorAfter(res.Cursor.After)
+		return items, after, err
+	}
+
+	return &ProjectSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// ProjectSearchAll calls project.search as many times as needed to collect all
+// results, following the result cursor. If maxItems is positive, at most
+// maxItems results are returned.
+func (c *Conn) ProjectSearchAll(
+	ctx context.Context,
+	req requests.ProjectSearchRequest,
+	maxItems int,
+) ([]*responses.ProjectSearchResponseItem, error) {
+	var items []*responses.ProjectSearchResponseItem
+	it := c.ProjectSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -8,10 +10,20 @@ import (
 // RemarkupProcess performs a call to remarkup.process
 func (c *Conn) RemarkupProcess(
 	req requests.RemarkupProcessRequest,
+) (*responses.RemarkupProcessResponse, error) {
+	ctx := context.Background()
+	return c.RemarkupProcessContext(ctx, req)
+}
+
+// RemarkupProcessContext performs a call to remarkup.process, passing through
+// the given context.
+func (c *Conn) RemarkupProcessContext(
+	ctx context.Context,
+	req requests.RemarkupProcessRequest,
 ) (*responses.RemarkupProcessResponse, error) {
 	var res responses.RemarkupProcessResponse
 
-	if err := c.Call("remarkup.process", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "remarkup.process", &req, &res); err != nil {
 		return nil, err
 	}
 
@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -8,10 +10,20 @@ import (
 // RepositoryQuery performs a call to repository.query.
 func (c *Conn) RepositoryQuery(
 	req requests.RepositoryQueryRequest,
+) (*responses.RepositoryQueryResponse, error) {
+	ctx := context.Background()
+	return c.RepositoryQueryContext(ctx, req)
+}
+
+// RepositoryQueryContext performs a call to repository.query, passing through
+// the given context.
+func (c *Conn) RepositoryQueryContext(
+	ctx context.Context,
+	req requests.RepositoryQueryRequest,
 ) (*responses.RepositoryQueryResponse, error) {
 	var res responses.RepositoryQueryResponse
 
-	if err := c.Call("repository.query", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "repository.query", &req, &res); err != nil {
 		return nil, err
 	}
 
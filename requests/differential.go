@@ -1,6 +1,8 @@
 package requests
 
 import (
+	"encoding/json"
+
 	"github.com/uber/gonduit/constants"
 	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/util"
@@ -126,3 +128,414 @@ type DifferentialDiffSearchConstraints struct {
 	PHIDs         []string `json:"phids,omitempty"`
 	RevisionPHIDs []string `json:"revisionPHIDs,omitempty"`
 }
+
+// DifferentialRevisionEditRequest represents a request to
+// differential.revision.edit. Transactions are added with the builder
+// methods:
+//
+//	req := requests.DifferentialRevisionEditRequest{ObjectIdentifier: "D123"}
+//	req.Accept().AddComment("LGTM")
+type DifferentialRevisionEditRequest struct {
+	// ObjectIdentifier is the ID, PHID or monogram of the revision to edit.
+	// A new revision is created if it is empty.
+	ObjectIdentifier string        `json:"objectIdentifier,omitempty"`
+	Transactions     []Transaction `json:"transactions"`
+	Request
+}
+
+// AddTransaction adds a transaction of any type to the request.
+func (r *DifferentialRevisionEditRequest) AddTransaction(
+	transactionType string,
+	value interface{},
+) *DifferentialRevisionEditRequest {
+	r.Transactions = append(r.Transactions, Transaction{
+		Type:  transactionType,
+		Value: value,
+	})
+
+	return r
+}
+
+// Update updates the revision to the diff with the given PHID.
+func (r *DifferentialRevisionEditRequest) Update(
+	diffPHID string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("update", diffPHID)
+}
+
+// SetTitle renames the revision.
+func (r *DifferentialRevisionEditRequest) SetTitle(
+	title string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("title", title)
+}
+
+// SetSummary changes the revision summary.
+func (r *DifferentialRevisionEditRequest) SetSummary(
+	summary string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("summary", summary)
+}
+
+// SetTestPlan changes the revision test plan.
+func (r *DifferentialRevisionEditRequest) SetTestPlan(
+	testPlan string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("testPlan", testPlan)
+}
+
+// SetRepository changes the repository the revision belongs to.
+func (r *DifferentialRevisionEditRequest) SetRepository(
+	repositoryPHID string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("repositoryPHID", repositoryPHID)
+}
+
+// AddReviewers adds reviewers to the revision.
+func (r *DifferentialRevisionEditRequest) AddReviewers(
+	reviewerPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("reviewers.add", reviewerPHIDs)
+}
+
+// AddBlockingReviewers adds reviewers to the revision whose approval is
+// required.
+func (r *DifferentialRevisionEditRequest) AddBlockingReviewers(
+	reviewerPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	blocking := make([]string, len(reviewerPHIDs))
+	for i, phid := range reviewerPHIDs {
+		blocking[i] = "blocking(" + phid + ")"
+	}
+
+	return r.AddTransaction("reviewers.add", blocking)
+}
+
+// RemoveReviewers removes reviewers from the revision.
+func (r *DifferentialRevisionEditRequest) RemoveReviewers(
+	reviewerPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("reviewers.remove", reviewerPHIDs)
+}
+
+// SetReviewers replaces the reviewers of the revision.
+func (r *DifferentialRevisionEditRequest) SetReviewers(
+	reviewerPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("reviewers.set", reviewerPHIDs)
+}
+
+// AddProjects adds tags to the revision.
+func (r *DifferentialRevisionEditRequest) AddProjects(
+	projectPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("projects.add", projectPHIDs)
+}
+
+// RemoveProjects removes tags from the revision.
+func (r *DifferentialRevisionEditRequest) RemoveProjects(
+	projectPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("projects.remove", projectPHIDs)
+}
+
+// SetProjects replaces the tags of the revision.
+func (r *DifferentialRevisionEditRequest) SetProjects(
+	projectPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("projects.set", projectPHIDs)
+}
+
+// AddSubscribers subscribes users or projects to the revision.
+func (r *DifferentialRevisionEditRequest) AddSubscribers(
+	subscriberPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("subscribers.add", subscriberPHIDs)
+}
+
+// RemoveSubscribers unsubscribes users or projects from the revision.
+func (r *DifferentialRevisionEditRequest) RemoveSubscribers(
+	subscriberPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("subscribers.remove", subscriberPHIDs)
+}
+
+// SetSubscribers replaces the subscribers of the revision.
+func (r *DifferentialRevisionEditRequest) SetSubscribers(
+	subscriberPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("subscribers.set", subscriberPHIDs)
+}
+
+// AddTasks associates tasks with the revision.
+func (r *DifferentialRevisionEditRequest) AddTasks(
+	taskPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("tasks.add", taskPHIDs)
+}
+
+// RemoveTasks dissociates tasks from the revision.
+func (r *DifferentialRevisionEditRequest) RemoveTasks(
+	taskPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("tasks.remove", taskPHIDs)
+}
+
+// SetTasks replaces the tasks associated with the revision.
+func (r *DifferentialRevisionEditRequest) SetTasks(
+	taskPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("tasks.set", taskPHIDs)
+}
+
+// AddParents adds parent revisions to the revision.
+func (r *DifferentialRevisionEditRequest) AddParents(
+	revisionPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("parents.add", revisionPHIDs)
+}
+
+// RemoveParents removes parent revisions from the revision.
+func (r *DifferentialRevisionEditRequest) RemoveParents(
+	revisionPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("parents.remove", revisionPHIDs)
+}
+
+// SetParents replaces the parent revisions of the revision.
+func (r *DifferentialRevisionEditRequest) SetParents(
+	revisionPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("parents.set", revisionPHIDs)
+}
+
+// AddChildren adds child revisions to the revision.
+func (r *DifferentialRevisionEditRequest) AddChildren(
+	revisionPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("children.add", revisionPHIDs)
+}
+
+// RemoveChildren removes child revisions from the revision.
+func (r *DifferentialRevisionEditRequest) RemoveChildren(
+	revisionPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("children.remove", revisionPHIDs)
+}
+
+// SetChildren replaces the child revisions of the revision.
+func (r *DifferentialRevisionEditRequest) SetChildren(
+	revisionPHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("children.set", revisionPHIDs)
+}
+
+// SetViewPolicy changes who can view the revision.
+func (r *DifferentialRevisionEditRequest) SetViewPolicy(
+	policy string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("view", policy)
+}
+
+// SetEditPolicy changes who can edit the revision.
+func (r *DifferentialRevisionEditRequest) SetEditPolicy(
+	policy string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("edit", policy)
+}
+
+// AddComment comments on the revision.
+func (r *DifferentialRevisionEditRequest) AddComment(
+	comment string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("comment", comment)
+}
+
+// PublishInlines publishes the draft inline comments with the given PHIDs.
+func (r *DifferentialRevisionEditRequest) PublishInlines(
+	inlinePHIDs ...string,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("inline", inlinePHIDs)
+}
+
+// Accept accepts the revision.
+func (r *DifferentialRevisionEditRequest) Accept() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("accept", true)
+}
+
+// Reject requests changes to the revision.
+func (r *DifferentialRevisionEditRequest) Reject() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("reject", true)
+}
+
+// RequestReview requests review of the revision.
+func (r *DifferentialRevisionEditRequest) RequestReview() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("request-review", true)
+}
+
+// PlanChanges plans changes to the revision.
+func (r *DifferentialRevisionEditRequest) PlanChanges() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("plan-changes", true)
+}
+
+// Resign resigns as a reviewer of the revision.
+func (r *DifferentialRevisionEditRequest) Resign() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("resign", true)
+}
+
+// Commandeer takes control of the revision.
+func (r *DifferentialRevisionEditRequest) Commandeer() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("commandeer", true)
+}
+
+// Abandon abandons the revision.
+func (r *DifferentialRevisionEditRequest) Abandon() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("abandon", true)
+}
+
+// Reclaim reclaims an abandoned revision.
+func (r *DifferentialRevisionEditRequest) Reclaim() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("reclaim", true)
+}
+
+// Close closes the revision.
+func (r *DifferentialRevisionEditRequest) Close() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("close", true)
+}
+
+// Reopen reopens a closed revision.
+func (r *DifferentialRevisionEditRequest) Reopen() *DifferentialRevisionEditRequest {
+	return r.AddTransaction("reopen", true)
+}
+
+// SetDraft holds the revision as a draft, or submits it for review.
+func (r *DifferentialRevisionEditRequest) SetDraft(
+	draft bool,
+) *DifferentialRevisionEditRequest {
+	return r.AddTransaction("draft", draft)
+}
+
+// DifferentialCreateRawDiffRequest represents a request to
+// differential.createrawdiff.
+type DifferentialCreateRawDiffRequest struct {
+	// Diff is a unified diff, such as the output of git diff.
+	Diff           string `json:"diff"`
+	RepositoryPHID string `json:"repositoryPHID,omitempty"`
+	ViewPolicy     string `json:"viewPolicy,omitempty"`
+	Request
+}
+
+// DifferentialCreateDiffRequest represents a request to
+// differential.creatediff. Changes can be parsed from a unified diff with
+// unidiff.Parse.
+type DifferentialCreateDiffRequest struct {
+	Changes                   []*entities.DifferentialChange   `json:"changes"`
+	SourceMachine             string                           `json:"sourceMachine"`
+	SourcePath                string                           `json:"sourcePath"`
+	Branch                    string                           `json:"branch"`
+	Bookmark                  string                           `json:"bookmark,omitempty"`
+	SourceControlSystem       string                           `json:"sourceControlSystem"`
+	SourceControlPath         string                           `json:"sourceControlPath"`
+	SourceControlBaseRevision string                           `json:"sourceControlBaseRevision"`
+	CreationMethod            string                           `json:"creationMethod,omitempty"`
+	LintStatus                constants.DifferentialLintStatus `json:"lintStatus"`
+	UnitStatus                constants.DifferentialUnitStatus `json:"unitStatus"`
+	RepositoryPHID            string                           `json:"repositoryPHID,omitempty"`
+	RepositoryUUID            string                           `json:"repositoryUUID,omitempty"`
+	ParentRevisionID          string                           `json:"parentRevisionID,omitempty"`
+	AuthorPHID                string                           `json:"authorPHID,omitempty"`
+	Request
+}
+
+// DifferentialGetRawDiffRequest represents a request to
+// differential.getrawdiff.
+type DifferentialGetRawDiffRequest struct {
+	DiffID int `json:"diffID"`
+	Request
+}
+
+// DifferentialChangesetSearchRequest represents a request to
+// differential.changeset.search API method.
+type DifferentialChangesetSearchRequest struct {
+	// QueryKey is builtin or saved query to use. It is optional and sets
+	// initial constraints.
+	QueryKey string `json:"queryKey,omitempty"`
+	// Constraints contains additional filters for results. Applied on top of
+	// query if provided.
+	Constraints *DifferentialChangesetSearchConstraints `json:"constraints,omitempty"`
+
+	*entities.Cursor
+	Request
+}
+
+// DifferentialChangesetSearchConstraints describes search criteria for
+// request.
+type DifferentialChangesetSearchConstraints struct {
+	IDs       []int    `json:"ids,omitempty"`
+	PHIDs     []string `json:"phids,omitempty"`
+	DiffPHIDs []string `json:"diffPHIDs,omitempty"`
+}
+
+// DifferentialCreateInlineRequest represents a request to
+// differential.createinline. It drafts an inline comment, which is published
+// with the next comment on the revision.
+//
+// differential.createinline has no parameter for the comment being replied
+// to, so inline comments drafted through conduit can not be replies. The
+// comment a published inline comment replies to is read from the
+// ReplyToCommentPHID field of its transaction.search result, see
+// Conn.DifferentialInlineComments.
+type DifferentialCreateInlineRequest struct {
+	RevisionID int `json:"revisionID,omitempty"`
+	// DiffID is the diff to comment on. The active diff of the revision is
+	// used if it is zero.
+	DiffID   int    `json:"diffID,omitempty"`
+	FilePath string `json:"filePath"`
+	// IsNewFile is true to comment on the new version of the file, false
+	// for the old one.
+	IsNewFile  bool `json:"isNewFile"`
+	LineNumber int  `json:"lineNumber"`
+	// LineLength is the number of lines after LineNumber the comment
+	// spans, zero for a single line.
+	LineLength int    `json:"lineLength,omitempty"`
+	Content    string `json:"content"`
+	Request
+}
+
+// DifferentialCreateCommentRequest represents a request to
+// differential.createcomment.
+type DifferentialCreateCommentRequest struct {
+	RevisionID int                                 `json:"revision_id"`
+	Message    string                              `json:"message,omitempty"`
+	Action     constants.DifferentialCommentAction `json:"action,omitempty"`
+	// Silent suppresses email notifications.
+	Silent bool `json:"silent,omitempty"`
+	// AttachInlines publishes the draft inline comments of the user.
+	AttachInlines bool `json:"attach_inlines,omitempty"`
+	Request
+}
+
+// DifferentialSetDiffPropertyRequest represents a request to
+// differential.setdiffproperty.
+type DifferentialSetDiffPropertyRequest struct {
+	DiffID int    `json:"diff_id"`
+	Name   string `json:"name"`
+	// Data is the JSON encoded value of the property. Use SetValue to
+	// encode it.
+	Data string `json:"data"`
+	Request
+}
+
+// SetValue sets Data to the JSON encoding of value.
+func (r *DifferentialSetDiffPropertyRequest) SetValue(
+	value interface{},
+) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	r.Data = string(data)
+
+	return nil
+}
@@ -0,0 +1,20 @@
+package requests
+
+import "strings"
+
+// Transaction is a single change applied to an object by an *.edit API
+// method.
+type Transaction struct {
+	Type  string      `json:"type"`
+	Value interface{} `json:"value"`
+}
+
+// customFieldTransactionType returns the transaction type editing the custom
+// field with the given key, e.g. "custom.acme:severity" for "acme:severity".
+func customFieldTransactionType(key string) string {
+	if strings.HasPrefix(key, "custom.") {
+		return key
+	}
+
+	return "custom." + key
+}
@@ -1,6 +1,11 @@
 package requests
 
 import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/uber/gonduit/constants"
 	"github.com/uber/gonduit/entities"
 )
 
@@ -27,3 +32,292 @@ type HarbormasterBuildableSearchConstraints struct {
 	Statuses       []entities.BuildableStatus `json:"statuses,omitempty"`
 	Manual         bool                       `json:"manual,omitempty"`
 }
+
+// HarbormasterSendMessageRequest represents a request to
+// harbormaster.sendmessage.
+type HarbormasterSendMessageRequest struct {
+	// Receiver is the PHID of the build target the message is sent to. It
+	// may also be a buildable, or a buildable object such as a diff, a
+	// revision or a commit, in which case the message goes to the
+	// autotarget of the object.
+	Receiver string                            `json:"receiver"`
+	Type     constants.HarbormasterMessageType `json:"type"`
+	Unit     []*HarbormasterUnitResult         `json:"unit,omitempty"`
+	Lint     []*HarbormasterLintMessage        `json:"lint,omitempty"`
+	Request
+}
+
+// ErrInvalidHarbormasterMessage is returned by
+// HarbormasterSendMessageRequest.Validate for messages conduit would reject.
+var ErrInvalidHarbormasterMessage = errors.New("invalid harbormaster message")
+
+// harbormasterReceiverTypes lists the types of objects
+// harbormaster.sendmessage accepts as receivers.
+var harbormasterReceiverTypes = []constants.PhidType{
+	constants.PhidTypeHarbormasterBuildTarget,
+	constants.PhidTypeHarbormasterBuildable,
+	constants.PhidTypeDifferentialDiff,
+	constants.PhidTypeDifferentialRevision,
+	constants.PhidTypeCommit,
+}
+
+// Validate checks that the receiver is the PHID of a build target, buildable
+// or buildable object, that the message type is known and that unit results
+// and lint messages have their required fields. The returned error wraps
+// ErrInvalidHarbormasterMessage.
+func (r *HarbormasterSendMessageRequest) Validate() error {
+	if !isPHIDOfType(r.Receiver, harbormasterReceiverTypes...) {
+		return fmt.Errorf(
+			"%w: receiver %q is not a build target or buildable PHID",
+			ErrInvalidHarbormasterMessage,
+			r.Receiver,
+		)
+	}
+
+	switch r.Type {
+	case constants.HarbormasterMessageTypePass,
+		constants.HarbormasterMessageTypeFail,
+		constants.HarbormasterMessageTypeWork:
+	default:
+		return fmt.Errorf(
+			"%w: unknown message type %q",
+			ErrInvalidHarbormasterMessage,
+			r.Type,
+		)
+	}
+
+	for i, unit := range r.Unit {
+		if unit == nil || unit.Name == "" || unit.Result == "" {
+			return fmt.Errorf(
+				"%w: unit result %d needs a name and a result",
+				ErrInvalidHarbormasterMessage,
+				i,
+			)
+		}
+	}
+
+	for i, lint := range r.Lint {
+		if lint == nil || lint.Name == "" || lint.Code == "" ||
+			lint.Severity == "" || lint.Path == "" {
+			return fmt.Errorf(
+				"%w: lint message %d needs a name, code, severity and path",
+				ErrInvalidHarbormasterMessage,
+				i,
+			)
+		}
+	}
+
+	return nil
+}
+
+// isPHIDOfType returns true if phid is a PHID of one of the given types,
+// such as "PHID-HMBT-abcdef" for PhidTypeHarbormasterBuildTarget.
+func isPHIDOfType(phid string, types ...constants.PhidType) bool {
+	for _, t := range types {
+		prefix := "PHID-" + string(t) + "-"
+		if strings.HasPrefix(phid, prefix) && len(phid) > len(prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HarbormasterUnitResult is the result of a unit test reported with
+// harbormaster.sendmessage.
+type HarbormasterUnitResult struct {
+	Name      string                                 `json:"name"`
+	Result    constants.HarbormasterUnitResultStatus `json:"result"`
+	Namespace string                                 `json:"namespace,omitempty"`
+	Engine    string                                 `json:"engine,omitempty"`
+	// Duration is the run time of the test in seconds.
+	Duration float64 `json:"duration,omitempty"`
+	Path     string  `json:"path,omitempty"`
+	// Coverage maps paths to their coverage strings, with one character
+	// per line: "N" not executable, "C" covered, "U" uncovered and "X"
+	// unreachable.
+	Coverage map[string]string `json:"coverage,omitempty"`
+	Details  string            `json:"details,omitempty"`
+	// Format is the format of Details, "text" or "remarkup".
+	Format string `json:"format,omitempty"`
+}
+
+// HarbormasterLintMessage is a lint message reported with
+// harbormaster.sendmessage.
+type HarbormasterLintMessage struct {
+	Name        string                             `json:"name"`
+	Code        string                             `json:"code"`
+	Severity    constants.HarbormasterLintSeverity `json:"severity"`
+	Path        string                             `json:"path"`
+	Line        int                                `json:"line,omitempty"`
+	Char        int                                `json:"char,omitempty"`
+	Description string                             `json:"description,omitempty"`
+}
+
+// HarbormasterBuildSearchRequest represents a request to
+// harbormaster.build.search API method.
+type HarbormasterBuildSearchRequest struct {
+	// QueryKey is builtin or saved query to use. It is optional and sets
+	// initial constraints.
+	QueryKey string `json:"queryKey,omitempty"`
+	// Constraints contains additional filters for results. Applied on top of
+	// query if provided.
+	Constraints *HarbormasterBuildSearchConstraints `json:"constraints,omitempty"`
+
+	*entities.Cursor
+	Request
+}
+
+// HarbormasterBuildSearchConstraints describes search criteria for request.
+type HarbormasterBuildSearchConstraints struct {
+	IDs   []int    `json:"ids,omitempty"`
+	PHIDs []string `json:"phids,omitempty"`
+	// Plans are PHIDs of build plans.
+	Plans []string `json:"plans,omitempty"`
+	// Buildables are PHIDs of buildables.
+	Buildables []string               `json:"buildables,omitempty"`
+	Statuses   []entities.BuildStatus `json:"statuses,omitempty"`
+	// Initiators are PHIDs of users or objects which started builds.
+	Initiators []string `json:"initiators,omitempty"`
+}
+
+// HarbormasterBuildTargetSearchRequest represents a request to
+// harbormaster.target.search API method.
+type HarbormasterBuildTargetSearchRequest struct {
+	// QueryKey is builtin or saved query to use. It is optional and sets
+	// initial constraints.
+	QueryKey string `json:"queryKey,omitempty"`
+	// Constraints contains additional filters for results. Applied on top of
+	// query if provided.
+	Constraints *HarbormasterBuildTargetSearchConstraints `json:"constraints,omitempty"`
+
+	*entities.Cursor
+	Request
+}
+
+// HarbormasterBuildTargetSearchConstraints describes search criteria for
+// request.
+type HarbormasterBuildTargetSearchConstraints struct {
+	IDs        []int    `json:"ids,omitempty"`
+	PHIDs      []string `json:"phids,omitempty"`
+	BuildPHIDs []string `json:"buildPHIDs,omitempty"`
+}
+
+// HarbormasterBuildPlanSearchRequest represents a request to
+// harbormaster.buildplan.search API method.
+type HarbormasterBuildPlanSearchRequest struct {
+	// QueryKey is builtin or saved query to use. It is optional and sets
+	// initial constraints.
+	QueryKey string `json:"queryKey,omitempty"`
+	// Constraints contains additional filters for results. Applied on top of
+	// query if provided.
+	Constraints *HarbormasterBuildPlanSearchConstraints `json:"constraints,omitempty"`
+
+	*entities.Cursor
+	Request
+}
+
+// HarbormasterBuildPlanSearchConstraints describes search criteria for
+// request.
+type HarbormasterBuildPlanSearchConstraints struct {
+	IDs   []int    `json:"ids,omitempty"`
+	PHIDs []string `json:"phids,omitempty"`
+	// Match is a full-text query on plan names.
+	Match string `json:"match,omitempty"`
+}
+
+// HarbormasterArtifactSearchRequest represents a request to
+// harbormaster.artifact.search API method.
+type HarbormasterArtifactSearchRequest struct {
+	// QueryKey is builtin or saved query to use. It is optional and sets
+	// initial constraints.
+	QueryKey string `json:"queryKey,omitempty"`
+	// Constraints contains additional filters for results. Applied on top of
+	// query if provided.
+	Constraints *HarbormasterArtifactSearchConstraints `json:"constraints,omitempty"`
+
+	*entities.Cursor
+	Request
+}
+
+// HarbormasterArtifactSearchConstraints describes search criteria for
+// request.
+type HarbormasterArtifactSearchConstraints struct {
+	IDs              []int    `json:"ids,omitempty"`
+	PHIDs            []string `json:"phids,omitempty"`
+	BuildTargetPHIDs []string `json:"buildTargetPHIDs,omitempty"`
+}
+
+// HarbormasterCreateArtifactRequest represents a request to
+// harbormaster.createartifact.
+type HarbormasterCreateArtifactRequest struct {
+	BuildTargetPHID string `json:"buildTargetPHID"`
+	// ArtifactKey identifies the artifact within the build plan.
+	ArtifactKey  string                `json:"artifactKey"`
+	ArtifactType entities.ArtifactType `json:"artifactType"`
+	// ArtifactData depends on ArtifactType, e.g. "uri", "name" and
+	// "ui.external" for entities.ArtifactTypeURI.
+	ArtifactData map[string]interface{} `json:"artifactData"`
+	Request
+}
+
+// HarbormasterBuildEditRequest represents a request to
+// harbormaster.build.edit. Commands are added with the builder methods:
+//
+//	req := requests.HarbormasterBuildEditRequest{ObjectIdentifier: "PHID-HMBD-1"}
+//	req.Restart()
+type HarbormasterBuildEditRequest struct {
+	// ObjectIdentifier is the ID or PHID of the build to edit.
+	ObjectIdentifier string        `json:"objectIdentifier"`
+	Transactions     []Transaction `json:"transactions"`
+	Request
+}
+
+// AddTransaction adds a transaction of any type to the request.
+func (r *HarbormasterBuildEditRequest) AddTransaction(
+	transactionType string,
+	value interface{},
+) *HarbormasterBuildEditRequest {
+	r.Transactions = append(r.Transactions, Transaction{
+		Type:  transactionType,
+		Value: value,
+	})
+
+	return r
+}
+
+// Command issues the given command to the build.
+func (r *HarbormasterBuildEditRequest) Command(
+	command constants.HarbormasterBuildCommand,
+) *HarbormasterBuildEditRequest {
+	return r.AddTransaction(string(command), true)
+}
+
+// Restart restarts the build.
+func (r *HarbormasterBuildEditRequest) Restart() *HarbormasterBuildEditRequest {
+	return r.Command(constants.HarbormasterBuildCommandRestart)
+}
+
+// Pause pauses the build.
+func (r *HarbormasterBuildEditRequest) Pause() *HarbormasterBuildEditRequest {
+	return r.Command(constants.HarbormasterBuildCommandPause)
+}
+
+// Resume resumes the build.
+func (r *HarbormasterBuildEditRequest) Resume() *HarbormasterBuildEditRequest {
+	return r.Command(constants.HarbormasterBuildCommandResume)
+}
+
+// Abort aborts the build.
+func (r *HarbormasterBuildEditRequest) Abort() *HarbormasterBuildEditRequest {
+	return r.Command(constants.HarbormasterBuildCommandAbort)
+}
+
+// HarbormasterCommandRequest represents a request to harbormaster.command,
+// the older form of harbormaster.build.edit.
+type HarbormasterCommandRequest struct {
+	// Receiver is the PHID of the build the command is issued to.
+	Receiver string                             `json:"receiver"`
+	Command  constants.HarbormasterBuildCommand `json:"command"`
+	Request
+}
@@ -151,3 +151,137 @@ type ManiphestSearchConstraints struct {
 	// Spaces - search for objects in certain spaces.
 	Spaces []string `json:"spaces,omitempty"`
 }
+
+// ManiphestEditRequest represents a request to maniphest.edit. Transactions
+// are added with the builder methods:
+//
+//	req := requests.ManiphestEditRequest{ObjectIdentifier: "T123"}
+//	req.SetStatus(constants.ManiphestEditStatusResolved).
+//		AddComment("Fixed in rABC123.")
+type ManiphestEditRequest struct {
+	// ObjectIdentifier is the ID, PHID or monogram of the task to edit. A
+	// new task is created if it is empty.
+	ObjectIdentifier string        `json:"objectIdentifier,omitempty"`
+	Transactions     []Transaction `json:"transactions"`
+	Request
+}
+
+// AddTransaction adds a transaction of any type to the request.
+func (r *ManiphestEditRequest) AddTransaction(
+	transactionType string,
+	value interface{},
+) *ManiphestEditRequest {
+	r.Transactions = append(r.Transactions, Transaction{
+		Type:  transactionType,
+		Value: value,
+	})
+
+	return r
+}
+
+// SetTitle renames the task.
+func (r *ManiphestEditRequest) SetTitle(title string) *ManiphestEditRequest {
+	return r.AddTransaction("title", title)
+}
+
+// SetDescription changes the task description.
+func (r *ManiphestEditRequest) SetDescription(
+	description string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("description", description)
+}
+
+// SetStatus changes the task status.
+func (r *ManiphestEditRequest) SetStatus(
+	status constants.ManiphestEditStatus,
+) *ManiphestEditRequest {
+	return r.AddTransaction("status", status)
+}
+
+// SetPriority changes the task priority.
+func (r *ManiphestEditRequest) SetPriority(
+	priority constants.ManiphestEditPriority,
+) *ManiphestEditRequest {
+	return r.AddTransaction("priority", priority)
+}
+
+// SetOwner assigns the task to the user with the given PHID, or unassigns it
+// if ownerPHID is empty.
+func (r *ManiphestEditRequest) SetOwner(ownerPHID string) *ManiphestEditRequest {
+	if ownerPHID == "" {
+		return r.AddTransaction("owner", nil)
+	}
+
+	return r.AddTransaction("owner", ownerPHID)
+}
+
+// AddProjects adds tags to the task.
+func (r *ManiphestEditRequest) AddProjects(
+	projectPHIDs ...string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("projects.add", projectPHIDs)
+}
+
+// RemoveProjects removes tags from the task.
+func (r *ManiphestEditRequest) RemoveProjects(
+	projectPHIDs ...string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("projects.remove", projectPHIDs)
+}
+
+// AddSubscribers subscribes users or projects to the task.
+func (r *ManiphestEditRequest) AddSubscribers(
+	subscriberPHIDs ...string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("subscribers.add", subscriberPHIDs)
+}
+
+// RemoveSubscribers unsubscribes users or projects from the task.
+func (r *ManiphestEditRequest) RemoveSubscribers(
+	subscriberPHIDs ...string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("subscribers.remove", subscriberPHIDs)
+}
+
+// SetParent makes the task a subtask of the task with the given PHID.
+func (r *ManiphestEditRequest) SetParent(
+	parentPHID string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("parent", parentPHID)
+}
+
+// AddSubtasks makes the tasks with the given PHIDs subtasks of the task.
+func (r *ManiphestEditRequest) AddSubtasks(
+	taskPHIDs ...string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("subtasks.add", taskPHIDs)
+}
+
+// RemoveSubtasks removes subtasks from the task.
+func (r *ManiphestEditRequest) RemoveSubtasks(
+	taskPHIDs ...string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("subtasks.remove", taskPHIDs)
+}
+
+// MoveToColumns moves the task to the workboard columns with the given
+// PHIDs.
+func (r *ManiphestEditRequest) MoveToColumns(
+	columnPHIDs ...string,
+) *ManiphestEditRequest {
+	return r.AddTransaction("column", columnPHIDs)
+}
+
+// AddComment comments on the task.
+func (r *ManiphestEditRequest) AddComment(comment string) *ManiphestEditRequest {
+	return r.AddTransaction("comment", comment)
+}
+
+// SetCustomField changes the value of the custom field with the given key,
+// e.g. "acme:severity".
+func (r *ManiphestEditRequest) SetCustomField(
+	key string,
+	value interface{},
+) *ManiphestEditRequest {
+	return r.AddTransaction(customFieldTransactionType(key), value)
+}
@@ -109,3 +109,75 @@ type DifferentialDiffRef struct {
 type DifferentialDiffSearchAttachments struct {
 	Commits SearchAttachmentCommits `json:"commits"`
 }
+
+// DifferentialCreateRawDiffResponse is the response of calling
+// differential.createrawdiff.
+type DifferentialCreateRawDiffResponse struct {
+	ID   int    `json:"id"`
+	PHID string `json:"phid"`
+	URI  string `json:"uri"`
+}
+
+// DifferentialCreateDiffResponse is the response of calling
+// differential.creatediff.
+type DifferentialCreateDiffResponse struct {
+	ID   int    `json:"diffid"`
+	PHID string `json:"phid"`
+	URI  string `json:"uri"`
+}
+
+// DifferentialGetRawDiffResponse is the response of calling
+// differential.getrawdiff. It is the unified diff of the diff.
+type DifferentialGetRawDiffResponse string
+
+// DifferentialChangesetSearchResponse contains fields that are in server
+// response to differential.changeset.search.
+type DifferentialChangesetSearchResponse struct {
+	// Data contains search results.
+	Data []*DifferentialChangesetSearchResponseItem `json:"data"`
+
+	// Cursor contains paging data.
+	Cursor SearchCursor `json:"cursor,omitempty"`
+}
+
+// DifferentialChangesetSearchResponseItem contains information about a
+// particular search result.
+type DifferentialChangesetSearchResponseItem struct {
+	ResponseObject
+	Fields DifferentialChangesetSearchResponseItemFields `json:"fields"`
+	SearchCursor
+}
+
+// DifferentialChangesetSearchResponseItemFields is a collection of object
+// fields.
+type DifferentialChangesetSearchResponseItemFields struct {
+	DiffPHID     string                    `json:"diffPHID"`
+	Path         DifferentialChangesetPath `json:"path"`
+	DateCreated  util.UnixTimestamp        `json:"dateCreated"`
+	DateModified util.UnixTimestamp        `json:"dateModified"`
+}
+
+// DifferentialChangesetPath is the path of a changeset.
+type DifferentialChangesetPath struct {
+	DisplayPath string `json:"displayPath"`
+}
+
+// DifferentialCreateInlineResponse is the response of calling
+// differential.createinline.
+type DifferentialCreateInlineResponse struct {
+	ID         int    `json:"id"`
+	AuthorPHID string `json:"authorPHID"`
+	FilePath   string `json:"filePath"`
+	IsNewFile  bool   `json:"isNewFile"`
+	LineNumber int    `json:"lineNumber"`
+	LineLength int    `json:"lineLength"`
+	DiffID     int    `json:"diffID"`
+	Content    string `json:"content"`
+}
+
+// DifferentialCreateCommentResponse is the response of calling
+// differential.createcomment.
+type DifferentialCreateCommentResponse struct {
+	RevisionID int    `json:"revisionid"`
+	URI        string `json:"uri"`
+}
@@ -0,0 +1,21 @@
+package responses
+
+// EditResponse is the response of calling an *.edit API method.
+type EditResponse struct {
+	// Object is the object which was created or edited.
+	Object EditResponseObject `json:"object"`
+	// Transactions are the transactions which were applied.
+	Transactions []EditResponseTransaction `json:"transactions"`
+}
+
+// EditResponseObject identifies the object edited by an *.edit API method.
+type EditResponseObject struct {
+	ID   int    `json:"id"`
+	PHID string `json:"phid"`
+}
+
+// EditResponseTransaction identifies a transaction applied by an *.edit API
+// method.
+type EditResponseTransaction struct {
+	PHID string `json:"phid"`
+}
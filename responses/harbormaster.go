@@ -39,3 +39,152 @@ type HarbormasterBuildableSearchResponseItemFields struct {
 type BuildableStatus struct {
 	Value entities.BuildableStatus `json:"value"`
 }
+
+// HarbormasterBuildSearchResponse contains fields that are in server
+// response to harbormaster.build.search.
+type HarbormasterBuildSearchResponse struct {
+	// Data contains search results.
+	Data []*HarbormasterBuildSearchResponseItem `json:"data"`
+
+	// Cursor contains paging data.
+	Cursor SearchCursor `json:"cursor,omitempty"`
+}
+
+// HarbormasterBuildSearchResponseItem contains information about a
+// particular search result.
+type HarbormasterBuildSearchResponseItem struct {
+	ResponseObject
+	Fields HarbormasterBuildSearchResponseItemFields `json:"fields"`
+	SearchCursor
+}
+
+// HarbormasterBuildSearchResponseItemFields is a collection of object
+// fields.
+type HarbormasterBuildSearchResponseItemFields struct {
+	BuildablePHID string             `json:"buildablePHID"`
+	BuildPlanPHID string             `json:"buildPlanPHID"`
+	BuildStatus   BuildStatus        `json:"buildStatus"`
+	InitiatorPHID string             `json:"initiatorPHID"`
+	Name          string             `json:"name"`
+	DateCreated   util.UnixTimestamp `json:"dateCreated"`
+	DateModified  util.UnixTimestamp `json:"dateModified"`
+}
+
+// BuildStatus is a container of build status value.
+type BuildStatus struct {
+	Value entities.BuildStatus `json:"value"`
+	Name  string               `json:"name"`
+}
+
+// HarbormasterBuildTargetSearchResponse contains fields that are in server
+// response to harbormaster.target.search.
+type HarbormasterBuildTargetSearchResponse struct {
+	// Data contains search results.
+	Data []*HarbormasterBuildTargetSearchResponseItem `json:"data"`
+
+	// Cursor contains paging data.
+	Cursor SearchCursor `json:"cursor,omitempty"`
+}
+
+// HarbormasterBuildTargetSearchResponseItem contains information about a
+// particular search result.
+type HarbormasterBuildTargetSearchResponseItem struct {
+	ResponseObject
+	Fields HarbormasterBuildTargetSearchResponseItemFields `json:"fields"`
+	SearchCursor
+}
+
+// HarbormasterBuildTargetSearchResponseItemFields is a collection of object
+// fields.
+type HarbormasterBuildTargetSearchResponseItemFields struct {
+	Name          string            `json:"name"`
+	BuildPHID     string            `json:"buildPHID"`
+	BuildStepPHID string            `json:"buildStepPHID"`
+	Status        BuildTargetStatus `json:"status"`
+	// EpochStarted is nil if the target has not started yet.
+	EpochStarted *util.UnixTimestamp `json:"epochStarted"`
+	// EpochCompleted is nil if the target has not completed yet.
+	EpochCompleted  *util.UnixTimestamp `json:"epochCompleted"`
+	BuildGeneration int                 `json:"buildGeneration"`
+	DateCreated     util.UnixTimestamp  `json:"dateCreated"`
+	DateModified    util.UnixTimestamp  `json:"dateModified"`
+}
+
+// BuildTargetStatus is a container of build target status value.
+type BuildTargetStatus struct {
+	Value entities.BuildTargetStatus `json:"value"`
+	Name  string                     `json:"name"`
+}
+
+// HarbormasterBuildPlanSearchResponse contains fields that are in server
+// response to harbormaster.buildplan.search.
+type HarbormasterBuildPlanSearchResponse struct {
+	// Data contains search results.
+	Data []*HarbormasterBuildPlanSearchResponseItem `json:"data"`
+
+	// Cursor contains paging data.
+	Cursor SearchCursor `json:"cursor,omitempty"`
+}
+
+// HarbormasterBuildPlanSearchResponseItem contains information about a
+// particular search result.
+type HarbormasterBuildPlanSearchResponseItem struct {
+	ResponseObject
+	Fields HarbormasterBuildPlanSearchResponseItemFields `json:"fields"`
+	SearchCursor
+}
+
+// HarbormasterBuildPlanSearchResponseItemFields is a collection of object
+// fields.
+type HarbormasterBuildPlanSearchResponseItemFields struct {
+	Name         string             `json:"name"`
+	Status       BuildPlanStatus    `json:"status"`
+	DateCreated  util.UnixTimestamp `json:"dateCreated"`
+	DateModified util.UnixTimestamp `json:"dateModified"`
+}
+
+// BuildPlanStatus is a container of build plan status value.
+type BuildPlanStatus struct {
+	Value entities.BuildPlanStatus `json:"value"`
+}
+
+// HarbormasterArtifactSearchResponse contains fields that are in server
+// response to harbormaster.artifact.search.
+type HarbormasterArtifactSearchResponse struct {
+	// Data contains search results.
+	Data []*HarbormasterArtifactSearchResponseItem `json:"data"`
+
+	// Cursor contains paging data.
+	Cursor SearchCursor `json:"cursor,omitempty"`
+}
+
+// HarbormasterArtifactSearchResponseItem contains information about a
+// particular search result.
+type HarbormasterArtifactSearchResponseItem struct {
+	ResponseObject
+	Fields HarbormasterArtifactSearchResponseItemFields `json:"fields"`
+	SearchCursor
+}
+
+// HarbormasterArtifactSearchResponseItemFields is a collection of object
+// fields.
+type HarbormasterArtifactSearchResponseItemFields struct {
+	BuildTargetPHID string                `json:"buildTargetPHID"`
+	ArtifactType    entities.ArtifactType `json:"artifactType"`
+	ArtifactKey     string                `json:"artifactKey"`
+	IsReleased      bool                  `json:"isReleased"`
+	DateCreated     util.UnixTimestamp    `json:"dateCreated"`
+	DateModified    util.UnixTimestamp    `json:"dateModified"`
+}
+
+// HarbormasterCreateArtifactResponse is the response of calling
+// harbormaster.createartifact.
+type HarbormasterCreateArtifactResponse struct {
+	// Data holds the created artifact.
+	Data []HarbormasterCreateArtifactResponseItem `json:"data"`
+}
+
+// HarbormasterCreateArtifactResponseItem identifies a created artifact.
+type HarbormasterCreateArtifactResponseItem struct {
+	PHID string `json:"phid"`
+}
@@ -30,6 +30,15 @@ type TransactionSearchResponseItem struct {
 	Comments     []TransactionSearchResponseItemComment `json:"comments"`
 }
 
+// IsInline returns true if the transaction is an inline comment on a
+// revision.
+func (t *TransactionSearchResponseItem) IsInline() bool {
+	return t.Type == TransactionTypeInline
+}
+
+// TransactionTypeInline is the type of inline comment transactions.
+const TransactionTypeInline = "inline"
+
 // TransactionSearchResponseItemFields is a collection of object
 // fields.
 type TransactionSearchResponseItemFields struct {
@@ -38,6 +47,14 @@ type TransactionSearchResponseItemFields struct {
 	New         string                                         `json:"new"`
 	Operations  []TransactionSearchResponseItemFieldsOperation `json:"operations"`
 	CommitPHIDs []string                                       `json:"commitPHIDs"`
+
+	// Fields of inline comment transactions.
+	Diff               *TransactionSearchResponseItemFieldsDiff `json:"diff"`
+	Path               string                                   `json:"path"`
+	Line               int                                      `json:"line"`
+	Length             int                                      `json:"length"`
+	ReplyToCommentPHID string                                   `json:"replyToCommentPHID"`
+	IsDone             bool                                     `json:"isDone"`
 }
 
 // special struct to fix issue when php return [] as empty "struct" which causes golang
@@ -47,6 +64,21 @@ type transactionSearchResponseItemFieldsDecoded struct {
 	New         string                                         `json:"new"`
 	Operations  []TransactionSearchResponseItemFieldsOperation `json:"operations"`
 	CommitPHIDs []string                                       `json:"commitPHIDs"`
+
+	// Fields of inline comment transactions.
+	Diff               *TransactionSearchResponseItemFieldsDiff `json:"diff"`
+	Path               string                                   `json:"path"`
+	Line               int                                      `json:"line"`
+	Length             int                                      `json:"length"`
+	ReplyToCommentPHID string                                   `json:"replyToCommentPHID"`
+	IsDone             bool                                     `json:"isDone"`
+}
+
+// TransactionSearchResponseItemFieldsDiff is the diff an inline comment
+// was made on.
+type TransactionSearchResponseItemFieldsDiff struct {
+	ID   int    `json:"id"`
+	PHID string `json:"phid"`
 }
 
 // TransactionSearchResponseItemComment is transaction comment
@@ -87,5 +119,11 @@ func (t *TransactionSearchResponseItemFields) UnmarshalJSON(data []byte) (err er
 	t.New = res.New
 	t.Operations = res.Operations
 	t.CommitPHIDs = res.CommitPHIDs
+	t.Diff = res.Diff
+	t.Path = res.Path
+	t.Line = res.Line
+	t.Length = res.Length
+	t.ReplyToCommentPHID = res.ReplyToCommentPHID
+	t.IsDone = res.IsDone
 	return err
 }
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"time"
 )
 
 // Server is a mock conduit server.
@@ -27,8 +28,14 @@ func New() *Server {
 type handlerResponse struct {
 	HTTPCode int
 	Payload  map[string]interface{}
+	Func     MethodFunc
+	Delay    time.Duration
 }
 
+// MethodFunc builds a response for a conduit API method from the decoded
+// request params. It returns the HTTP code and the response payload.
+type MethodFunc func(params map[string]interface{}) (int, map[string]interface{})
+
 type handler struct {
 	routes map[string]handlerResponse
 }
@@ -53,17 +60,51 @@ func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	data, err := json.Marshal(response.Payload)
+	if response.Delay > 0 {
+		// Consume the body first, so that the server notices when the client
+		// goes away and cancels the request context.
+		req.ParseForm()
+		select {
+		case <-time.After(response.Delay):
+		case <-req.Context().Done():
+			return
+		}
+	}
+
+	httpCode, payload := response.HTTPCode, response.Payload
+	if response.Func != nil {
+		params, err := decodeParams(req)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		httpCode, payload = response.Func(params)
+	}
+
+	data, err := json.Marshal(payload)
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(response.HTTPCode)
+	w.WriteHeader(httpCode)
 	w.Write(data)
 }
 
+// decodeParams extracts the JSON encoded params of a conduit request.
+func decodeParams(req *http.Request) (map[string]interface{}, error) {
+	params := make(map[string]interface{})
+	raw := req.FormValue("params")
+	if raw == "" {
+		return params, nil
+	}
+	if err := json.Unmarshal([]byte(raw), &params); err != nil {
+		return nil, err
+	}
+	return params, nil
+}
+
 func (h *handler) RegisterMethod(
 	method string,
 	httpCode int,
@@ -76,6 +117,25 @@ func (h *handler) RegisterMethod(
 
 }
 
+func (h *handler) RegisterMethodFunc(method string, fn MethodFunc) {
+	h.routes[method] = handlerResponse{
+		Func: fn,
+	}
+}
+
+func (h *handler) RegisterSlowMethod(
+	method string,
+	httpCode int,
+	delay time.Duration,
+	response map[string]interface{},
+) {
+	h.routes[method] = handlerResponse{
+		HTTPCode: httpCode,
+		Payload:  response,
+		Delay:    delay,
+	}
+}
+
 // ResponseFromJSON builds a response map expected by RegisterMethod from a raw
 // JSON provided as bytes slice.
 func ResponseFromJSON(data string) map[string]interface{} {
@@ -119,6 +179,25 @@ func (s *Server) RegisterMethod(
 	s.handler.RegisterMethod(fmt.Sprintf("/api/%s", method), httpCode, response)
 }
 
+// RegisterMethodFunc adds a handler for a specific conduit API method which
+// builds the response from the request params on every call.
+func (s *Server) RegisterMethodFunc(method string, fn MethodFunc) {
+	s.handler.RegisterMethodFunc(fmt.Sprintf("/api/%s", method), fn)
+}
+
+// RegisterSlowMethod adds a handler for a specific conduit API method which
+// waits for delay before responding, or until the client goes away. It is
+// useful to exercise deadlines and cancellation.
+func (s *Server) RegisterSlowMethod(
+	method string,
+	httpCode int,
+	delay time.Duration,
+	response map[string]interface{},
+) {
+	s.handler.RegisterSlowMethod(
+		fmt.Sprintf("/api/%s", method), httpCode, delay, response)
+}
+
 // GetURL returns the URL of the root of the server.
 func (s *Server) GetURL() string {
 	return s.server.URL
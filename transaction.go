@@ -1,6 +1,9 @@
 package gonduit
 
 import (
+	"context"
+
+	"github.com/uber/gonduit/entities"
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -10,12 +13,81 @@ const TransactionSearchMethod = "transaction.search"
 // TransactionSearch performs a call to transaction.search.
 func (c *Conn) TransactionSearch(
 	req requests.TransactionSearchRequest,
+) (*responses.TransactionSearchResponse, error) {
+	ctx := context.Background()
+	return c.TransactionSearchContext(ctx, req)
+}
+
+// TransactionSearchContext performs a call to transaction.search, passing
+// through the given context.
+func (c *Conn) TransactionSearchContext(
+	ctx context.Context,
+	req requests.TransactionSearchRequest,
 ) (*responses.TransactionSearchResponse, error) {
 	var res responses.TransactionSearchResponse
 
-	if err := c.Call(TransactionSearchMethod, &req, &res); err != nil {
+	if err := c.CallContext(
+		ctx, TransactionSearchMethod, &req, &res); err != nil {
 		return nil, err
 	}
 
 	return &res, nil
 }
+
+// TransactionSearchIterator iterates over all results of transaction.search.
+type TransactionSearchIterator struct {
+	*SearchIterator
+}
+
+// Item returns the current result. It is only valid after a call to Next
+// returned true.
+func (it *TransactionSearchIterator) Item() *responses.TransactionSearchResponseItem {
+	item, _ := it.item.(*responses.TransactionSearchResponseItem)
+	return item
+}
+
+// TransactionSearchIter returns an iterator over all results of
+// transaction.search, following the result cursor starting at req.Cursor. If
+// maxItems is positive, at most maxItems results are returned.
+func (c *Conn) TransactionSearchIter(
+	ctx context.Context,
+	req requests.TransactionSearchRequest,
+	maxItems int,
+) *TransactionSearchIterator {
+	fetch := func(
+		ctx context.Context,
+		cursor *entities.Cursor,
+	) ([]interface{}, uint64, error) {
+		req.Cursor = cursor
+		res, err := c.TransactionSearchContext(ctx, req)
+		if err != nil {
+			return nil, 0, err
+		}
+		items := make([]interface{}, len(res.Data))
+		for i, item := range res.Data {
+			items[i] = item
+		}
+		return items, res.Cursor.After, nil
+	}
+
+	return &TransactionSearchIterator{newSearchIterator(ctx, req.Cursor, maxItems, fetch)}
+}
+
+// TransactionSearchAll calls transaction.search as many times as needed to
+// collect all results, following the result cursor. If maxItems is positive, at
+// most maxItems results are returned.
+func (c *Conn) TransactionSearchAll(
+	ctx context.Context,
+	req requests.TransactionSearchRequest,
+	maxItems int,
+) ([]*responses.TransactionSearchResponseItem, error) {
+	var items []*responses.TransactionSearchResponseItem
+	it := c.TransactionSearchIter(ctx, req, maxItems)
+	for it.Next() {
+		items = append(items, it.Item())
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -0,0 +1,157 @@
+package unidiff
+
+import (
+	"strings"
+
+	"github.com/uber/gonduit/constants"
+	"github.com/uber/gonduit/entities"
+)
+
+// LineKind is the kind of a line of a hunk.
+type LineKind byte
+
+const (
+	// LineContext is an unchanged line.
+	LineContext LineKind = ' '
+	// LineAdded is an added line.
+	LineAdded LineKind = '+'
+	// LineRemoved is a removed line.
+	LineRemoved LineKind = '-'
+)
+
+// Line is a line of a hunk.
+type Line struct {
+	Kind LineKind
+	// Text is the content of the line, without its prefix and newline.
+	Text string
+	// OldNumber is the number of the line in the old file, or 0 for added
+	// lines.
+	OldNumber int
+	// NewNumber is the number of the line in the new file, or 0 for removed
+	// lines.
+	NewNumber int
+}
+
+// Hunk is a range of lines changed in a file.
+type Hunk struct {
+	OldStart int
+	OldLines int
+	NewStart int
+	NewLines int
+	Lines    []Line
+}
+
+// File is a file changed by a diff.
+type File struct {
+	// OldPath is the path of the file before the change, or empty if the
+	// file was added.
+	OldPath string
+	// NewPath is the path of the file after the change, or empty if the
+	// file was deleted.
+	NewPath string
+	Type    constants.DifferentialChangeType
+	Binary  bool
+	Hunks   []Hunk
+}
+
+// AddedLines returns the lines added to the file.
+func (f *File) AddedLines() []Line {
+	return f.lines(LineAdded)
+}
+
+// RemovedLines returns the lines removed from the file.
+func (f *File) RemovedLines() []Line {
+	return f.lines(LineRemoved)
+}
+
+func (f *File) lines(kind LineKind) []Line {
+	var lines []Line
+	for _, hunk := range f.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Kind == kind {
+				lines = append(lines, line)
+			}
+		}
+	}
+
+	return lines
+}
+
+// ParseFiles parses a unified diff, such as the one returned by
+// differential.getrawdiff, into the files it changes. Unlike ParseString,
+// moved and copied files are only returned once, with their old and new
+// paths.
+func ParseFiles(diff string) ([]*File, error) {
+	changes, err := ParseString(diff)
+	if err != nil {
+		return nil, err
+	}
+
+	var files []*File
+	for _, change := range changes {
+		switch change.Type {
+		case constants.DifferentialChangeTypeMoveAway,
+			constants.DifferentialChangeTypeCopyAway,
+			constants.DifferentialChangeTypeMultiCopy:
+			continue
+		}
+
+		files = append(files, newFile(change))
+	}
+
+	return files, nil
+}
+
+func newFile(change *entities.DifferentialChange) *File {
+	f := &File{
+		OldPath: change.OldPath,
+		NewPath: change.CurrentPath,
+		Type:    change.Type,
+		Binary:  change.FileType == constants.DifferentialFileTypeBinary,
+	}
+
+	if change.Type == constants.DifferentialChangeTypeDelete {
+		f.NewPath = ""
+	}
+
+	for _, hunk := range change.Hunks {
+		f.Hunks = append(f.Hunks, newHunk(hunk))
+	}
+
+	return f
+}
+
+func newHunk(hunk *entities.DifferentialHunk) Hunk {
+	h := Hunk{
+		OldStart: hunk.OldOffset,
+		OldLines: hunk.OldLength,
+		NewStart: hunk.NewOffset,
+		NewLines: hunk.NewLength,
+	}
+
+	oldNumber, newNumber := hunk.OldOffset, hunk.NewOffset
+	for _, text := range splitLines(hunk.Corpus) {
+		line := Line{
+			Kind: LineKind(text[0]),
+			Text: strings.TrimSuffix(strings.TrimSuffix(text[1:], "\n"), "\r"),
+		}
+
+		switch line.Kind {
+		case LineAdded:
+			line.NewNumber = newNumber
+			newNumber++
+		case LineRemoved:
+			line.OldNumber = oldNumber
+			oldNumber++
+		default:
+			line.OldNumber = oldNumber
+			line.NewNumber = newNumber
+			oldNumber++
+			newNumber++
+		}
+
+		h.Lines = append(h.Lines, line)
+	}
+
+	return h
+}
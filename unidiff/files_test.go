@@ -0,0 +1,71 @@
+package unidiff
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/constants"
+)
+
+func TestParseFiles(t *testing.T) {
+	files, err := ParseFiles(gitDiff)
+	assert.Nil(t, err)
+
+	if !assert.Len(t, files, 6) {
+		return
+	}
+
+	main := files[0]
+	assert.Equal(t, "main.go", main.OldPath)
+	assert.Equal(t, "main.go", main.NewPath)
+	assert.Equal(t, constants.DifferentialChangeTypeChange, main.Type)
+	assert.Equal(t, []Hunk{
+		{
+			OldStart: 1,
+			OldLines: 4,
+			NewStart: 1,
+			NewLines: 5,
+			Lines: []Line{
+				{Kind: LineContext, Text: "package main", OldNumber: 1, NewNumber: 1},
+				{Kind: LineContext, Text: "", OldNumber: 2, NewNumber: 2},
+				{Kind: LineRemoved, Text: "func main() {}", OldNumber: 3},
+				{Kind: LineAdded, Text: "func main() {", NewNumber: 3},
+				{Kind: LineAdded, Text: "}", NewNumber: 4},
+				{Kind: LineContext, Text: "// end", OldNumber: 4, NewNumber: 5},
+			},
+		},
+		{
+			OldStart: 10,
+			OldLines: 1,
+			NewStart: 11,
+			NewLines: 1,
+			Lines: []Line{
+				{Kind: LineRemoved, Text: "\treturn 1", OldNumber: 10},
+				{Kind: LineAdded, Text: "\treturn 2", NewNumber: 11},
+			},
+		},
+	}, main.Hunks)
+
+	assert.Equal(t, []Line{
+		{Kind: LineAdded, Text: "func main() {", NewNumber: 3},
+		{Kind: LineAdded, Text: "}", NewNumber: 4},
+		{Kind: LineAdded, Text: "\treturn 2", NewNumber: 11},
+	}, main.AddedLines())
+	assert.Len(t, main.RemovedLines(), 2)
+
+	added := files[1]
+	assert.Equal(t, "", added.OldPath)
+	assert.Equal(t, "docs/new.md", added.NewPath)
+
+	deleted := files[2]
+	assert.Equal(t, "old.txt", deleted.OldPath)
+	assert.Equal(t, "", deleted.NewPath)
+
+	moved := files[3]
+	assert.Equal(t, "a.txt", moved.OldPath)
+	assert.Equal(t, "b.txt", moved.NewPath)
+	assert.Equal(t, constants.DifferentialChangeTypeMoveHere, moved.Type)
+
+	assert.True(t, files[5].Binary)
+	assert.Empty(t, files[5].Hunks)
+}
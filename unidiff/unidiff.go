@@ -0,0 +1,443 @@
+// Package unidiff parses unified diffs, such as the output of git diff, into
+// the changes expected by differential.creatediff.
+package unidiff
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/uber/gonduit/constants"
+	"github.com/uber/gonduit/entities"
+)
+
+const devNull = "/dev/null"
+
+var hunkHeader = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// Parse reads a unified diff and returns its changes.
+func Parse(r io.Reader) ([]*entities.DifferentialChange, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseString(string(data))
+}
+
+// ParseString parses a unified diff and returns its changes. Both git diffs,
+// including renames, copies, mode changes and binary files, and plain
+// unified diffs are supported. Text before the first file, such as a commit
+// message, is ignored.
+func ParseString(diff string) ([]*entities.DifferentialChange, error) {
+	p := parser{lines: splitLines(diff)}
+
+	if err := p.parse(); err != nil {
+		return nil, err
+	}
+
+	return p.changes, nil
+}
+
+// file is a file of the diff, as described by its headers.
+type file struct {
+	oldPath string
+	newPath string
+	oldMode string
+	newMode string
+	added   bool
+	deleted bool
+	copied  bool
+	renamed bool
+	binary  bool
+	hunks   []*entities.DifferentialHunk
+}
+
+type parser struct {
+	lines   []string
+	pos     int
+	changes []*entities.DifferentialChange
+}
+
+func (p *parser) parse() error {
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			if err := p.parseGitFile(); err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "--- ") &&
+			p.pos+1 < len(p.lines) &&
+			strings.HasPrefix(p.lines[p.pos+1], "+++ "):
+			if err := p.parsePlainFile(); err != nil {
+				return err
+			}
+		default:
+			p.pos++
+		}
+	}
+
+	return nil
+}
+
+// parseGitFile parses a file starting with a "diff --git" header.
+func (p *parser) parseGitFile() error {
+	var f file
+	f.oldPath, f.newPath = parseGitHeader(trimLine(p.lines[p.pos]))
+	p.pos++
+
+	for ; p.pos < len(p.lines); p.pos++ {
+		line := trimLine(p.lines[p.pos])
+
+		switch {
+		case strings.HasPrefix(line, "diff --git "),
+			strings.HasPrefix(line, "@@ "):
+			return p.finishFile(&f)
+		case strings.HasPrefix(line, "old mode "):
+			f.oldMode = strings.TrimPrefix(line, "old mode ")
+		case strings.HasPrefix(line, "new mode "):
+			f.newMode = strings.TrimPrefix(line, "new mode ")
+		case strings.HasPrefix(line, "deleted file mode "):
+			f.deleted = true
+			f.oldMode = strings.TrimPrefix(line, "deleted file mode ")
+		case strings.HasPrefix(line, "new file mode "):
+			f.added = true
+			f.newMode = strings.TrimPrefix(line, "new file mode ")
+		case strings.HasPrefix(line, "rename from "):
+			f.renamed = true
+			f.oldPath = unquotePath(strings.TrimPrefix(line, "rename from "))
+		case strings.HasPrefix(line, "rename to "):
+			f.newPath = unquotePath(strings.TrimPrefix(line, "rename to "))
+		case strings.HasPrefix(line, "copy from "):
+			f.copied = true
+			f.oldPath = unquotePath(strings.TrimPrefix(line, "copy from "))
+		case strings.HasPrefix(line, "copy to "):
+			f.newPath = unquotePath(strings.TrimPrefix(line, "copy to "))
+		case strings.HasPrefix(line, "--- "):
+			if path := parseFileHeader(line, "--- ", "a/"); path != devNull {
+				f.oldPath = path
+			}
+		case strings.HasPrefix(line, "+++ "):
+			if path := parseFileHeader(line, "+++ ", "b/"); path != devNull {
+				f.newPath = path
+			}
+		case strings.HasPrefix(line, "Binary files "),
+			strings.HasPrefix(line, "GIT binary patch"):
+			f.binary = true
+		}
+	}
+
+	return p.finishFile(&f)
+}
+
+// parsePlainFile parses a file starting with "---" and "+++" headers, as
+// produced by diff -u.
+func (p *parser) parsePlainFile() error {
+	var f file
+
+	oldPath := parseFileHeader(trimLine(p.lines[p.pos]), "--- ", "")
+	newPath := parseFileHeader(trimLine(p.lines[p.pos+1]), "+++ ", "")
+	p.pos += 2
+
+	if strings.HasPrefix(oldPath, "a/") && strings.HasPrefix(newPath, "b/") {
+		oldPath, newPath = oldPath[2:], newPath[2:]
+	}
+
+	switch {
+	case oldPath == devNull:
+		f.added = true
+		f.newPath = newPath
+	case newPath == devNull:
+		f.deleted = true
+		f.oldPath = oldPath
+	default:
+		f.oldPath, f.newPath = oldPath, newPath
+	}
+
+	return p.finishFile(&f)
+}
+
+// finishFile parses the hunks of the file and adds its changes.
+func (p *parser) finishFile(f *file) error {
+	for p.pos < len(p.lines) && strings.HasPrefix(p.lines[p.pos], "@@ ") {
+		hunk, err := p.parseHunk()
+		if err != nil {
+			return err
+		}
+
+		f.hunks = append(f.hunks, hunk)
+	}
+
+	p.addChanges(f)
+
+	return nil
+}
+
+// parseHunk parses a hunk starting with a "@@" header.
+func (p *parser) parseHunk() (*entities.DifferentialHunk, error) {
+	header := hunkHeader.FindStringSubmatch(p.lines[p.pos])
+	if header == nil {
+		return nil, p.errorf("invalid hunk header %q", trimLine(p.lines[p.pos]))
+	}
+	p.pos++
+
+	hunk := &entities.DifferentialHunk{
+		OldOffset: atoi(header[1], 0),
+		OldLength: atoi(header[2], 1),
+		NewOffset: atoi(header[3], 0),
+		NewLength: atoi(header[4], 1),
+	}
+
+	var corpus strings.Builder
+	oldLeft, newLeft := hunk.OldLength, hunk.NewLength
+	last := byte(' ')
+
+	for p.pos < len(p.lines) {
+		line := p.lines[p.pos]
+
+		if strings.HasPrefix(line, `\`) {
+			// "\ No newline at end of file" applies to the previous line.
+			switch last {
+			case '-':
+				hunk.IsMissingOldNewline = true
+			case '+':
+				hunk.IsMissingNewNewline = true
+			default:
+				hunk.IsMissingOldNewline = true
+				hunk.IsMissingNewNewline = true
+			}
+
+			p.pos++
+			continue
+		}
+
+		if oldLeft <= 0 && newLeft <= 0 {
+			break
+		}
+
+		// Some tools strip the trailing space of empty context lines. The
+		// line ending is kept, as it may be CRLF.
+		if trimLine(line) == "" {
+			line = " " + line
+		}
+
+		switch line[0] {
+		case ' ':
+			oldLeft--
+			newLeft--
+		case '-':
+			oldLeft--
+			hunk.DelLines++
+		case '+':
+			newLeft--
+			hunk.AddLines++
+		default:
+			return nil, p.errorf("unexpected line in hunk %q", trimLine(line))
+		}
+
+		if oldLeft < 0 || newLeft < 0 {
+			return nil, p.errorf("hunk is longer than its header")
+		}
+
+		last = line[0]
+		corpus.WriteString(line)
+		if !strings.HasSuffix(line, "\n") {
+			corpus.WriteString("\n")
+		}
+
+		p.pos++
+	}
+
+	if oldLeft > 0 || newLeft > 0 {
+		return nil, p.errorf("hunk is shorter than its header")
+	}
+
+	hunk.Corpus = corpus.String()
+
+	return hunk, nil
+}
+
+// addChanges adds the changes describing the file. Moves and copies are
+// described by a change of the destination and one of the source.
+func (p *parser) addChanges(f *file) {
+	change := entities.NewDifferentialChange()
+	change.Hunks = append(change.Hunks, f.hunks...)
+	change.FileType = fileType(f)
+
+	if f.oldMode != "" {
+		change.OldProperties["unix:filemode"] = f.oldMode
+	}
+
+	if f.newMode != "" {
+		change.NewProperties["unix:filemode"] = f.newMode
+	}
+
+	switch {
+	case f.added:
+		change.Type = constants.DifferentialChangeTypeAdd
+		change.CurrentPath = f.newPath
+	case f.deleted:
+		change.Type = constants.DifferentialChangeTypeDelete
+		change.OldPath = f.oldPath
+		change.CurrentPath = f.oldPath
+	case f.renamed:
+		change.Type = constants.DifferentialChangeTypeMoveHere
+		change.OldPath = f.oldPath
+		change.CurrentPath = f.newPath
+		p.addAwayPath(f.oldPath, f.newPath, constants.DifferentialChangeTypeMoveAway)
+	case f.copied:
+		change.Type = constants.DifferentialChangeTypeCopyHere
+		change.OldPath = f.oldPath
+		change.CurrentPath = f.newPath
+		p.addAwayPath(f.oldPath, f.newPath, constants.DifferentialChangeTypeCopyAway)
+	default:
+		change.Type = constants.DifferentialChangeTypeChange
+		change.OldPath = f.oldPath
+		change.CurrentPath = f.newPath
+	}
+
+	p.changes = append(p.changes, change)
+}
+
+// addAwayPath records that path was moved or copied to awayPath.
+func (p *parser) addAwayPath(
+	path string,
+	awayPath string,
+	changeType constants.DifferentialChangeType,
+) {
+	for _, change := range p.changes {
+		if change.CurrentPath != path ||
+			(change.Type != constants.DifferentialChangeTypeCopyAway &&
+				change.Type != constants.DifferentialChangeTypeMultiCopy) {
+			continue
+		}
+
+		change.AwayPaths = append(change.AwayPaths, awayPath)
+		change.Type = constants.DifferentialChangeTypeMultiCopy
+
+		return
+	}
+
+	away := entities.NewDifferentialChange()
+	away.Type = changeType
+	away.OldPath = path
+	away.CurrentPath = path
+	away.AwayPaths = append(away.AwayPaths, awayPath)
+	away.FileType = constants.DifferentialFileTypeText
+
+	p.changes = append(p.changes, away)
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf(
+		"unidiff: line %d: %s",
+		p.pos+1,
+		fmt.Sprintf(format, args...),
+	)
+}
+
+func fileType(f *file) constants.DifferentialFileType {
+	switch {
+	case f.binary:
+		return constants.DifferentialFileTypeBinary
+	case f.newMode == "120000" || (f.deleted && f.oldMode == "120000"):
+		return constants.DifferentialFileTypeSymlink
+	default:
+		return constants.DifferentialFileTypeText
+	}
+}
+
+// parseGitHeader returns the paths of a "diff --git a/old b/new" header.
+func parseGitHeader(line string) (string, string) {
+	paths := strings.TrimPrefix(line, "diff --git ")
+
+	if strings.HasPrefix(paths, `"`) {
+		if end := closingQuote(paths); end > 0 {
+			oldPath := unquotePath(paths[:end+1])
+			newPath := unquotePath(strings.TrimSpace(paths[end+1:]))
+
+			return strings.TrimPrefix(oldPath, "a/"), strings.TrimPrefix(newPath, "b/")
+		}
+	}
+
+	if i := strings.LastIndex(paths, " b/"); i >= 0 {
+		return strings.TrimPrefix(paths[:i], "a/"), paths[i+len(" b/"):]
+	}
+
+	return paths, paths
+}
+
+// parseFileHeader returns the path of a "---" or "+++" header, without the
+// git prefix and the timestamp appended by diff -u.
+func parseFileHeader(line, header, prefix string) string {
+	path := strings.TrimPrefix(line, header)
+
+	if i := strings.Index(path, "\t"); i >= 0 {
+		path = path[:i]
+	}
+
+	path = unquotePath(path)
+	if path == devNull {
+		return path
+	}
+
+	return strings.TrimPrefix(path, prefix)
+}
+
+// unquotePath unquotes paths quoted by git because they contain special
+// characters.
+func unquotePath(path string) string {
+	if strings.HasPrefix(path, `"`) {
+		if unquoted, err := strconv.Unquote(path); err == nil {
+			return unquoted
+		}
+	}
+
+	return path
+}
+
+// closingQuote returns the index of the quote closing the string starting
+// with a quote, or -1.
+func closingQuote(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+
+	return -1
+}
+
+func splitLines(s string) []string {
+	lines := strings.SplitAfter(s, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+func trimLine(line string) string {
+	return strings.TrimRight(line, "\r\n")
+}
+
+func atoi(s string, fallback int) int {
+	if s == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(s)
+	if err != nil {
+		return fallback
+	}
+
+	return n
+}
@@ -0,0 +1,280 @@
+package unidiff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/uber/gonduit/constants"
+	"github.com/uber/gonduit/entities"
+)
+
+const gitDiff = `commit 0123456789abcdef
+Author: Alice <alice@example.com>
+
+    Fix the build
+
+diff --git a/main.go b/main.go
+index 83db48f..bf269f4 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,5 @@
+ package main
+ 
+-func main() {}
++func main() {
++}
+ // end
+@@ -10 +11 @@ func other() {
+-	return 1
++	return 2
+diff --git a/docs/new.md b/docs/new.md
+new file mode 100644
+index 0000000..e69de29
+--- /dev/null
++++ b/docs/new.md
+@@ -0,0 +1,2 @@
++# New
++text
+\ No newline at end of file
+diff --git a/old.txt b/old.txt
+deleted file mode 100644
+index 257cc56..0000000
+--- a/old.txt
++++ /dev/null
+@@ -1 +0,0 @@
+-foo
+diff --git a/a.txt b/b.txt
+similarity index 90%
+rename from a.txt
+rename to b.txt
+index 257cc56..5716ca5 100644
+--- a/a.txt
++++ b/b.txt
+@@ -1 +1 @@
+-foo
++bar
+diff --git a/run.sh b/run.sh
+old mode 100644
+new mode 100755
+diff --git a/logo.png b/logo.png
+index 1111111..2222222 100644
+Binary files a/logo.png and b/logo.png differ
+`
+
+func TestParseString_withGitDiff(t *testing.T) {
+	changes, err := ParseString(gitDiff)
+	assert.Nil(t, err)
+
+	if !assert.Len(t, changes, 7) {
+		return
+	}
+
+	modified := changes[0]
+	assert.Equal(t, constants.DifferentialChangeTypeChange, modified.Type)
+	assert.Equal(t, constants.DifferentialFileTypeText, modified.FileType)
+	assert.Equal(t, "main.go", modified.OldPath)
+	assert.Equal(t, "main.go", modified.CurrentPath)
+	assert.Equal(t, []*entities.DifferentialHunk{
+		{
+			OldOffset: 1,
+			OldLength: 4,
+			NewOffset: 1,
+			NewLength: 5,
+			AddLines:  2,
+			DelLines:  1,
+			Corpus: " package main\n \n-func main() {}\n+func main() {\n" +
+				"+}\n // end\n",
+		},
+		{
+			OldOffset: 10,
+			OldLength: 1,
+			NewOffset: 11,
+			NewLength: 1,
+			AddLines:  1,
+			DelLines:  1,
+			Corpus:    "-\treturn 1\n+\treturn 2\n",
+		},
+	}, modified.Hunks)
+
+	added := changes[1]
+	assert.Equal(t, constants.DifferentialChangeTypeAdd, added.Type)
+	assert.Equal(t, "", added.OldPath)
+	assert.Equal(t, "docs/new.md", added.CurrentPath)
+	assert.Equal(t, map[string]string{"unix:filemode": "100644"}, added.NewProperties)
+	assert.True(t, added.Hunks[0].IsMissingNewNewline)
+	assert.False(t, added.Hunks[0].IsMissingOldNewline)
+	assert.Equal(t, "+# New\n+text\n", added.Hunks[0].Corpus)
+
+	deleted := changes[2]
+	assert.Equal(t, constants.DifferentialChangeTypeDelete, deleted.Type)
+	assert.Equal(t, "old.txt", deleted.CurrentPath)
+	assert.Equal(t, 1, deleted.Hunks[0].DelLines)
+
+	moveAway := changes[3]
+	assert.Equal(t, constants.DifferentialChangeTypeMoveAway, moveAway.Type)
+	assert.Equal(t, "a.txt", moveAway.CurrentPath)
+	assert.Equal(t, []string{"b.txt"}, moveAway.AwayPaths)
+
+	moveHere := changes[4]
+	assert.Equal(t, constants.DifferentialChangeTypeMoveHere, moveHere.Type)
+	assert.Equal(t, "a.txt", moveHere.OldPath)
+	assert.Equal(t, "b.txt", moveHere.CurrentPath)
+	assert.Len(t, moveHere.Hunks, 1)
+
+	modeChange := changes[5]
+	assert.Equal(t, constants.DifferentialChangeTypeChange, modeChange.Type)
+	assert.Equal(t, "run.sh", modeChange.CurrentPath)
+	assert.Equal(t, "100644", modeChange.OldProperties["unix:filemode"])
+	assert.Equal(t, "100755", modeChange.NewProperties["unix:filemode"])
+	assert.Empty(t, modeChange.Hunks)
+
+	binary := changes[6]
+	assert.Equal(t, constants.DifferentialFileTypeBinary, binary.FileType)
+	assert.Equal(t, "logo.png", binary.CurrentPath)
+	assert.Empty(t, binary.Hunks)
+}
+
+func TestParseString_withCopies(t *testing.T) {
+	changes, err := ParseString(`diff --git a/a.txt b/b.txt
+similarity index 100%
+copy from a.txt
+copy to b.txt
+diff --git a/a.txt b/c.txt
+similarity index 100%
+copy from a.txt
+copy to c.txt
+`)
+	assert.Nil(t, err)
+
+	if assert.Len(t, changes, 3) {
+		assert.Equal(t, constants.DifferentialChangeTypeMultiCopy, changes[0].Type)
+		assert.Equal(t, []string{"b.txt", "c.txt"}, changes[0].AwayPaths)
+		assert.Equal(t, constants.DifferentialChangeTypeCopyHere, changes[1].Type)
+		assert.Equal(t, "a.txt", changes[1].OldPath)
+		assert.Equal(t, constants.DifferentialChangeTypeCopyHere, changes[2].Type)
+		assert.Equal(t, "c.txt", changes[2].CurrentPath)
+	}
+}
+
+func TestParseString_withQuotedPaths(t *testing.T) {
+	changes, err := ParseString(`diff --git "a/with space.txt" "b/with space.txt"
+--- "a/with space.txt"
++++ "b/with space.txt"
+@@ -1 +1 @@
+-a
++b
+`)
+	assert.Nil(t, err)
+
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, "with space.txt", changes[0].OldPath)
+		assert.Equal(t, "with space.txt", changes[0].CurrentPath)
+	}
+}
+
+func TestParse_withPlainDiff(t *testing.T) {
+	changes, err := Parse(strings.NewReader(`--- main.go	2020-01-01 00:00:00.000000000 +0000
++++ main.go	2020-01-02 00:00:00.000000000 +0000
+@@ -1,2 +1,2 @@
+ package main
+-var x = 1
+\ No newline at end of file
++var x = 2
+\ No newline at end of file
+--- /dev/null	2020-01-01 00:00:00.000000000 +0000
++++ new.go	2020-01-02 00:00:00.000000000 +0000
+@@ -0,0 +1 @@
++package main
+`))
+	assert.Nil(t, err)
+
+	if assert.Len(t, changes, 2) {
+		assert.Equal(t, constants.DifferentialChangeTypeChange, changes[0].Type)
+		assert.Equal(t, "main.go", changes[0].CurrentPath)
+		assert.True(t, changes[0].Hunks[0].IsMissingOldNewline)
+		assert.True(t, changes[0].Hunks[0].IsMissingNewNewline)
+		assert.Equal(
+			t,
+			" package main\n-var x = 1\n+var x = 2\n",
+			changes[0].Hunks[0].Corpus,
+		)
+
+		assert.Equal(t, constants.DifferentialChangeTypeAdd, changes[1].Type)
+		assert.Equal(t, "new.go", changes[1].CurrentPath)
+	}
+}
+
+func TestParseString_withCRLF(t *testing.T) {
+	diff := strings.Replace(`diff --git a/main.go b/main.go
+index 1111111..2222222 100644
+--- a/main.go
++++ b/main.go
+@@ -1,4 +1,4 @@
+ package main
+
+-var x = 1
++var x = 2
+ // end
+`, "\n", "\r\n", -1)
+
+	changes, err := ParseString(diff)
+	assert.Nil(t, err)
+
+	if assert.Len(t, changes, 1) {
+		assert.Equal(t, "main.go", changes[0].CurrentPath)
+		if assert.Len(t, changes[0].Hunks, 1) {
+			hunk := changes[0].Hunks[0]
+			assert.Equal(t, 1, hunk.AddLines)
+			assert.Equal(t, 1, hunk.DelLines)
+			assert.Equal(
+				t,
+				" package main\r\n \r\n-var x = 1\r\n+var x = 2\r\n // end\r\n",
+				hunk.Corpus,
+			)
+		}
+	}
+
+	files, err := ParseFiles(diff)
+	assert.Nil(t, err)
+	if assert.Len(t, files, 1) {
+		assert.Equal(t, []Line{
+			{Kind: LineContext, Text: "package main", OldNumber: 1, NewNumber: 1},
+			{Kind: LineContext, Text: "", OldNumber: 2, NewNumber: 2},
+			{Kind: LineRemoved, Text: "var x = 1", OldNumber: 3},
+			{Kind: LineAdded, Text: "var x = 2", NewNumber: 3},
+			{Kind: LineContext, Text: "// end", OldNumber: 4, NewNumber: 4},
+		}, files[0].Hunks[0].Lines)
+	}
+}
+
+func TestParseString_withInvalidHunk(t *testing.T) {
+	_, err := ParseString(`--- a/main.go
++++ b/main.go
+@@ -1,3 +1,3 @@
+ package main
+-var x = 1
+`)
+
+	assert.EqualError(t, err, "unidiff: line 6: hunk is shorter than its header")
+
+	_, err = ParseString(`--- a/main.go
++++ b/main.go
+@@ -1,2 +1,2 @@
+ package main
+*var x = 1
+`)
+
+	assert.EqualError(
+		t,
+		err,
+		`unidiff: line 5: unexpected line in hunk "*var x = 1"`,
+	)
+}
+
+func TestParseString_withEmpty(t *testing.T) {
+	changes, err := ParseString("")
+
+	assert.Nil(t, err)
+	assert.Empty(t, changes)
+}
@@ -1,6 +1,8 @@
 package gonduit
 
 import (
+	"context"
+
 	"github.com/uber/gonduit/requests"
 	"github.com/uber/gonduit/responses"
 )
@@ -8,10 +10,20 @@ import (
 // UserQuery performs a call to differential.query.
 func (c *Conn) UserQuery(
 	req requests.UserQueryRequest,
+) (*responses.UserQueryResponse, error) {
+	ctx := context.Background()
+	return c.UserQueryContext(ctx, req)
+}
+
+// UserQueryContext performs a call to differential.query, passing through the
+// given context.
+func (c *Conn) UserQueryContext(
+	ctx context.Context,
+	req requests.UserQueryRequest,
 ) (*responses.UserQueryResponse, error) {
 	var res responses.UserQueryResponse
 
-	if err := c.Call("user.query", &req, &res); err != nil {
+	if err := c.CallContext(ctx, "user.query", &req, &res); err != nil {
 		return nil, err
 	}
 